@@ -0,0 +1,133 @@
+package eventsub
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffPolicy is a policy of retrying failed attempts to connect to the eventsub websocket server.
+type BackoffPolicy interface {
+	// Attempts returns maximum number of attempts, zero means unlimited attempts.
+	Attempts() uint
+	// Delay returns delay before the next attempt after the provided number of failed attempts (starting from 1)
+	// that failed with the error.
+	Delay(failedAttempts uint, err error) time.Duration
+	// Retryable returns if attempt that failed with the error should be retried.
+	Retryable(err error) bool
+}
+
+// ConstantBackoff is a BackoffPolicy with constant delay between attempts.
+type ConstantBackoff struct {
+	// MaxAttempts is a maximum number of attempts, zero means unlimited attempts.
+	MaxAttempts uint
+	// Interval is a delay between attempts.
+	Interval time.Duration
+}
+
+var _ BackoffPolicy = ConstantBackoff{}
+
+func (cb ConstantBackoff) Attempts() uint {
+	return cb.MaxAttempts
+}
+
+func (cb ConstantBackoff) Delay(_ uint, err error) time.Duration {
+	if isImmediateRetryError(err) {
+		return 0
+	}
+
+	return cb.Interval
+}
+
+func (cb ConstantBackoff) Retryable(err error) bool {
+	return IsRetryableError(err)
+}
+
+// ExponentialBackoff is a BackoffPolicy with exponentially growing delay between attempts and full jitter, so clients
+// that were disconnected at the same time don't reconnect at the same time.
+//
+// Reference: https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter.
+type ExponentialBackoff struct {
+	// MaxAttempts is a maximum number of attempts, zero means unlimited attempts.
+	MaxAttempts uint
+	// BaseDelay is a delay before jitter after the first failed attempt, it's doubled after each failed attempt.
+	BaseDelay time.Duration
+	// MaxDelay is a maximum delay before jitter, zero means no limit.
+	MaxDelay time.Duration
+	// IsRetryable classifies errors that should be retried, IsRetryableError is used if it's nil.
+	IsRetryable func(error) bool
+}
+
+var _ BackoffPolicy = ExponentialBackoff{}
+
+// DefaultExponentialBackoff returns ExponentialBackoff with unlimited attempts, 1 second base delay and 2 minutes
+// maximum delay.
+func DefaultExponentialBackoff() ExponentialBackoff {
+	return ExponentialBackoff{
+		BaseDelay: 1 * time.Second,
+		MaxDelay:  2 * time.Minute,
+	}
+}
+
+func (eb ExponentialBackoff) Attempts() uint {
+	return eb.MaxAttempts
+}
+
+func (eb ExponentialBackoff) Delay(failedAttempts uint, err error) time.Duration {
+	const maxShift = 32
+
+	if isImmediateRetryError(err) {
+		return 0
+	}
+
+	failedAttempts = max(failedAttempts, 1)
+
+	// Delay is clamped to the maximum duration instead of overflowing after shift.
+	delay := time.Duration(math.MaxInt64)
+	if shift := min(failedAttempts-1, maxShift); eb.BaseDelay <= delay>>shift {
+		delay = eb.BaseDelay << shift
+	}
+
+	if eb.MaxDelay > 0 {
+		delay = min(delay, eb.MaxDelay)
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	// There is no point in jitter of the maximum duration, and delay + 1 would overflow.
+	if delay == math.MaxInt64 {
+		return delay
+	}
+
+	return rand.N(delay + 1)
+}
+
+func (eb ExponentialBackoff) Retryable(err error) bool {
+	if eb.IsRetryable != nil {
+		return eb.IsRetryable(err)
+	}
+
+	return IsRetryableError(err)
+}
+
+// IsRetryableError is a default classification of errors for BackoffPolicy. Connection is not retried if it was
+// closed with code of CloseClassFatal (e.g. as unused when there were no subscriptions), as a new connection will be
+// closed for the same reason.
+func IsRetryableError(err error) bool {
+	if class, ok := CloseClassOf(err); ok {
+		return class != CloseClassFatal
+	}
+
+	return !errors.Is(err, ErrConnectionUnused)
+}
+
+// isImmediateRetryError reports if connection closed with the error should be retried without delay, as it was closed
+// because of failed reconnect and a new connection to the default server URL is expected to succeed.
+func isImmediateRetryError(err error) bool {
+	class, ok := CloseClassOf(err)
+
+	return ok && class == CloseClassReconnect
+}
@@ -1,64 +1,62 @@
 package eventsub
 
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
 // Handler is a handler for generic event.
 type Handler[Event any, Metadata any] func(Event, Metadata)
 
+// ContextHandler is a context-aware handler for generic event that can fail.
+//
+// Returned error is reported to OnHandlerError callback, or, if Webhook runs handlers synchronously (see
+// WebhookWithSynchronousHandlers), makes Webhook respond with non-2xx status code, so Twitch redelivers notification.
+type ContextHandler[Event any, Metadata any] func(context.Context, Event, Metadata) error
+
+// withContext converts handler to ContextHandler that never fails.
+func (h Handler[Event, Metadata]) withContext() ContextHandler[Event, Metadata] {
+	if h == nil {
+		return nil
+	}
+
+	return func(_ context.Context, event Event, metadata Metadata) error {
+		h(event, metadata)
+		return nil
+	}
+}
+
 // callback is a store for user's EventSub callbacks.
+//
+// Several handlers can be registered for the same event, each event callback setter (e.g. OnChannelFollow) returns
+// function that removes the registered handler. Callbacks are safe to set while client is running.
 type callback[Metadata any] struct {
+	dispatcher
+
+	// synchronous means that handlers run synchronously with processing of the message, so their errors are returned.
+	synchronous bool
+
+	// mu guards middlewares, events and callbacks that are not event handlers.
+	mu          sync.RWMutex
+	middlewares []Middleware
+
 	onDuplicate      func(Metadata)
 	onUndefinedEvent func(RawEvent, Metadata)
+	onHandlerError   func(error, Metadata)
 
-	onAutomodMessageHold                          Handler[AutomodMessageHoldEvent, Metadata]
-	onAutomodMessageHoldV2                        Handler[AutomodMessageHoldEventV2, Metadata]
-	onAutomodMessageUpdate                        Handler[AutomodMessageUpdateEvent, Metadata]
-	onAutomodMessageUpdateV2                      Handler[AutomodMessageUpdateEventV2, Metadata]
-	onAutomodSettingsUpdate                       Handler[AutomodSettingsUpdateEvent, Metadata]
-	onAutomodTermsUpdate                          Handler[AutomodTermsUpdateEvent, Metadata]
-	onChannelBitsUse                              Handler[ChannelBitsUseEvent, Metadata]
-	onChannelUpdate                               Handler[ChannelUpdateEvent, Metadata]
-	onChannelFollow                               Handler[ChannelFollowEvent, Metadata]
-	onChannelAdBreakBegin                         Handler[ChannelAdBreakBeginEvent, Metadata]
-	onChannelChatClear                            Handler[ChannelChatClearEvent, Metadata]
-	onChannelChatClearUserMessages                Handler[ChannelChatClearUserMessagesEvent, Metadata]
-	onChannelChatMessage                          Handler[ChannelChatMessageEvent, Metadata]
-	onConduitShardDisabled                        Handler[ConduitShardDisabledEvent, Metadata]
-	onChannelBan                                  Handler[ChannelBanEvent, Metadata]
-	onChannelUnban                                Handler[ChannelUnbanEvent, Metadata]
-	onChannelChatNotification                     Handler[ChannelChatNotificationEvent, Metadata]
-	onChannelModeratorAdd                         Handler[ChannelModeratorAddEvent, Metadata]
-	onChannelModeratorRemove                      Handler[ChannelModeratorRemoveEvent, Metadata]
-	onChannelPollBegin                            Handler[ChannelPollBeginEvent, Metadata]
-	onChannelPollProgress                         Handler[ChannelPollProgressEvent, Metadata]
-	onChannelPollEnd                              Handler[ChannelPollEndEvent, Metadata]
-	onChannelPredictionBegin                      Handler[ChannelPredictionBeginEvent, Metadata]
-	onChannelPredictionProgress                   Handler[ChannelPredictionProgressEvent, Metadata]
-	onChannelPredictionLock                       Handler[ChannelPredictionLockEvent, Metadata]
-	onChannelPredictionEnd                        Handler[ChannelPredictionEndEvent, Metadata]
-	onChannelRaid                                 Handler[ChannelRaidEvent, Metadata]
-	onChannelPointsCustomRewardRedemptionAdd      Handler[ChannelPointsCustomRewardRedemptionAddEvent, Metadata]
-	onChannelPointsCustomRewardRedemptionUpdate   Handler[ChannelPointsCustomRewardRedemptionUpdateEvent, Metadata]
-	onChannelPointsAutomaticRewardRedemptionAdd   Handler[ChannelPointsAutomaticRewardRedemptionAddEvent, Metadata]
-	onChannelPointsAutomaticRewardRedemptionAddV2 Handler[ChannelPointsAutomaticRewardRedemptionAddEventV2, Metadata]
-	onChannelPointsCustomRewardAdd                Handler[ChannelPointsCustomRewardAddEvent, Metadata]
-	onChannelPointsCustomRewardUpdate             Handler[ChannelPointsCustomRewardUpdateEvent, Metadata]
-	onChannelPointsCustomRewardRemove             Handler[ChannelPointsCustomRewardRemoveEvent, Metadata]
-	onStreamOffline                               Handler[StreamOfflineEvent, Metadata]
-	onStreamOnline                                Handler[StreamOnlineEvent, Metadata]
-	onChannelSubscribe                            Handler[ChannelSubscribeEvent, Metadata]
-	onChannelSubscriptionEnd                      Handler[ChannelSubscriptionEndEvent, Metadata]
-	onChannelSubscriptionMessage                  Handler[ChannelSubscriptionMessageEvent, Metadata]
-	onChannelSubscriptionGift                     Handler[ChannelSubscriptionGiftEvent, Metadata]
-	onChannelUnbanRequestCreate                   Handler[ChannelUnbanRequestCreateEvent, Metadata]
-	onChannelUnbanRequestResolve                  Handler[ChannelUnbanRequestResolveEvent, Metadata]
-	onUserUpdate                                  Handler[UserUpdateEvent, Metadata]
-	onChannelVipAdd                               Handler[ChannelVipAddEvent, Metadata]
-	onChannelVipRemove                            Handler[ChannelVipRemoveEvent, Metadata]
-	onChannelChatMessageDelete                    Handler[ChannelChatMessageDeleteEvent, Metadata]
-	onUserAuthorizationRevoke                     Handler[UserAuthorizationRevokeEvent, Metadata]
+	// events maps Go type of the event to its handlers.
+	events map[reflect.Type]*handlers[any, Metadata]
+
+	// onAny is a list of handlers of every decoded event, including the ones that feed Events channels.
+	onAny handlers[Envelope, Metadata]
 }
 
 // OnDuplicate invokes when duplicate message is caught (this is not necessarily an event).
 func (c *callback[Metadata]) OnDuplicate(onDuplicate func(Metadata)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.onDuplicate = onDuplicate
 }
 
@@ -66,333 +64,622 @@ func (c *callback[Metadata]) OnDuplicate(onDuplicate func(Metadata)) {
 //
 // If this callback handler is set then ErrUndefinedEventType error will not be returned from event callback runner.
 func (c *callback[Metadata]) OnUndefinedEvent(onUndefinedEvent func(RawEvent, Metadata)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.onUndefinedEvent = onUndefinedEvent
 }
 
+// OnHandlerError invokes when ContextHandler returns error.
+func (c *callback[Metadata]) OnHandlerError(onHandlerError func(error, Metadata)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onHandlerError = onHandlerError
+}
+
+// addHandler adds type-erased handler of the event with the provided Go type.
+func (c *callback[Metadata]) addHandler(goType reflect.Type, handler ContextHandler[any, Metadata]) func() {
+	c.mu.Lock()
+
+	if c.events == nil {
+		c.events = make(map[reflect.Type]*handlers[any, Metadata])
+	}
+
+	eventHandlers, ok := c.events[goType]
+	if !ok {
+		eventHandlers = new(handlers[any, Metadata])
+		c.events[goType] = eventHandlers
+	}
+
+	c.mu.Unlock()
+
+	return eventHandlers.add(handler)
+}
+
+// handlersOf returns handlers of the event with the provided Go type, or nil if there are no handlers.
+func (c *callback[Metadata]) handlersOf(goType reflect.Type) *handlers[any, Metadata] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.events[goType]
+}
+
+func (c *callback[Metadata]) getOnDuplicate() func(Metadata) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.onDuplicate
+}
+
+func (c *callback[Metadata]) getOnUndefinedEvent() func(RawEvent, Metadata) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.onUndefinedEvent
+}
+
+func (c *callback[Metadata]) getOnHandlerError() func(error, Metadata) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.onHandlerError
+}
+
 // OnAutomodMessageHold invokes when message is caught by automod for review.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#automodmessagehold.
-func (c *callback[Metadata]) OnAutomodMessageHold(onAutomodMessageHold Handler[AutomodMessageHoldEvent, Metadata]) {
-	c.onAutomodMessageHold = onAutomodMessageHold
+func (c *callback[Metadata]) OnAutomodMessageHold(onAutomodMessageHold Handler[AutomodMessageHoldEvent, Metadata]) func() {
+	return OnContext(c, onAutomodMessageHold.withContext())
+}
+
+// OnAutomodMessageHoldContext is the same as OnAutomodMessageHold, but handler receives context and returns error.
+func (c *callback[Metadata]) OnAutomodMessageHoldContext(onAutomodMessageHold ContextHandler[AutomodMessageHoldEvent, Metadata]) func() {
+	return OnContext(c, onAutomodMessageHold)
 }
 
 // OnAutomodMessageHoldV2 invokes when message is caught by automod for review.
 // Only public blocked terms trigger notifications, not private ones.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#automodmessagehold-v2.
-func (c *callback[Metadata]) OnAutomodMessageHoldV2(onAutomodMessageHoldV2 Handler[AutomodMessageHoldEventV2, Metadata]) {
-	c.onAutomodMessageHoldV2 = onAutomodMessageHoldV2
+func (c *callback[Metadata]) OnAutomodMessageHoldV2(onAutomodMessageHoldV2 Handler[AutomodMessageHoldEventV2, Metadata]) func() {
+	return OnContext(c, onAutomodMessageHoldV2.withContext())
+}
+
+// OnAutomodMessageHoldV2Context is the same as OnAutomodMessageHoldV2, but handler receives context and returns error.
+func (c *callback[Metadata]) OnAutomodMessageHoldV2Context(onAutomodMessageHoldV2 ContextHandler[AutomodMessageHoldEventV2, Metadata]) func() {
+	return OnContext(c, onAutomodMessageHoldV2)
 }
 
 // OnAutomodMessageUpdate invokes when a message in the automod queue had its status changed.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#automodmessageupdate.
-func (c *callback[Metadata]) OnAutomodMessageUpdate(onAutomodMessageUpdate Handler[AutomodMessageUpdateEvent, Metadata]) {
-	c.onAutomodMessageUpdate = onAutomodMessageUpdate
+func (c *callback[Metadata]) OnAutomodMessageUpdate(onAutomodMessageUpdate Handler[AutomodMessageUpdateEvent, Metadata]) func() {
+	return OnContext(c, onAutomodMessageUpdate.withContext())
+}
+
+// OnAutomodMessageUpdateContext is the same as OnAutomodMessageUpdate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnAutomodMessageUpdateContext(onAutomodMessageUpdate ContextHandler[AutomodMessageUpdateEvent, Metadata]) func() {
+	return OnContext(c, onAutomodMessageUpdate)
 }
 
 // OnAutomodMessageUpdateV2 invokes when a message in the automod queue had its status changed. Only public blocked terms
 // trigger notifications, not private ones.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#automodmessageupdate-v2.
-func (c *callback[Metadata]) OnAutomodMessageUpdateV2(onAutomodMessageUpdateV2 Handler[AutomodMessageUpdateEventV2, Metadata]) {
-	c.onAutomodMessageUpdateV2 = onAutomodMessageUpdateV2
+func (c *callback[Metadata]) OnAutomodMessageUpdateV2(onAutomodMessageUpdateV2 Handler[AutomodMessageUpdateEventV2, Metadata]) func() {
+	return OnContext(c, onAutomodMessageUpdateV2.withContext())
+}
+
+// OnAutomodMessageUpdateV2Context is the same as OnAutomodMessageUpdateV2, but handler receives context and returns error.
+func (c *callback[Metadata]) OnAutomodMessageUpdateV2Context(onAutomodMessageUpdateV2 ContextHandler[AutomodMessageUpdateEventV2, Metadata]) func() {
+	return OnContext(c, onAutomodMessageUpdateV2)
 }
 
 // OnAutomodSettingsUpdate invokes when  a broadcaster’s automod settings are updated.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#automodsettingsupdate.
-func (c *callback[Metadata]) OnAutomodSettingsUpdate(onAutomodSettingsUpdate Handler[AutomodSettingsUpdateEvent, Metadata]) {
-	c.onAutomodSettingsUpdate = onAutomodSettingsUpdate
+func (c *callback[Metadata]) OnAutomodSettingsUpdate(onAutomodSettingsUpdate Handler[AutomodSettingsUpdateEvent, Metadata]) func() {
+	return OnContext(c, onAutomodSettingsUpdate.withContext())
+}
+
+// OnAutomodSettingsUpdateContext is the same as OnAutomodSettingsUpdate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnAutomodSettingsUpdateContext(onAutomodSettingsUpdate ContextHandler[AutomodSettingsUpdateEvent, Metadata]) func() {
+	return OnContext(c, onAutomodSettingsUpdate)
 }
 
 // OnAutomodTermsUpdate invokes when a broadcaster’s automod terms are updated. Changes to private terms are not sent.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#automodtermsupdate.
-func (c *callback[Metadata]) OnAutomodTermsUpdate(onAutomodTermsUpdate Handler[AutomodTermsUpdateEvent, Metadata]) {
-	c.onAutomodTermsUpdate = onAutomodTermsUpdate
+func (c *callback[Metadata]) OnAutomodTermsUpdate(onAutomodTermsUpdate Handler[AutomodTermsUpdateEvent, Metadata]) func() {
+	return OnContext(c, onAutomodTermsUpdate.withContext())
+}
+
+// OnAutomodTermsUpdateContext is the same as OnAutomodTermsUpdate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnAutomodTermsUpdateContext(onAutomodTermsUpdate ContextHandler[AutomodTermsUpdateEvent, Metadata]) func() {
+	return OnContext(c, onAutomodTermsUpdate)
 }
 
 // OnChannelBitsUse invokes when bits are used on a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelbitsuse.
-func (c *callback[Metadata]) OnChannelBitsUse(onChannelBitsUse Handler[ChannelBitsUseEvent, Metadata]) {
-	c.onChannelBitsUse = onChannelBitsUse
+func (c *callback[Metadata]) OnChannelBitsUse(onChannelBitsUse Handler[ChannelBitsUseEvent, Metadata]) func() {
+	return OnContext(c, onChannelBitsUse.withContext())
+}
+
+// OnChannelBitsUseContext is the same as OnChannelBitsUse, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelBitsUseContext(onChannelBitsUse ContextHandler[ChannelBitsUseEvent, Metadata]) func() {
+	return OnContext(c, onChannelBitsUse)
 }
 
 // OnChannelUpdate invokes when a broadcaster updates the category, title, content classification labels, or broadcast
 // language for their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelupdate.
-func (c *callback[Metadata]) OnChannelUpdate(onChannelUpdate Handler[ChannelUpdateEvent, Metadata]) {
-	c.onChannelUpdate = onChannelUpdate
+func (c *callback[Metadata]) OnChannelUpdate(onChannelUpdate Handler[ChannelUpdateEvent, Metadata]) func() {
+	return OnContext(c, onChannelUpdate.withContext())
+}
+
+// OnChannelUpdateContext is the same as OnChannelUpdate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelUpdateContext(onChannelUpdate ContextHandler[ChannelUpdateEvent, Metadata]) func() {
+	return OnContext(c, onChannelUpdate)
 }
 
 // OnChannelFollow invokes when a specified channel receives a follow.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelfollow
-func (c *callback[Metadata]) OnChannelFollow(onChannelFollow Handler[ChannelFollowEvent, Metadata]) {
-	c.onChannelFollow = onChannelFollow
+func (c *callback[Metadata]) OnChannelFollow(onChannelFollow Handler[ChannelFollowEvent, Metadata]) func() {
+	return OnContext(c, onChannelFollow.withContext())
+}
+
+// OnChannelFollowContext is the same as OnChannelFollow, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelFollowContext(onChannelFollow ContextHandler[ChannelFollowEvent, Metadata]) func() {
+	return OnContext(c, onChannelFollow)
 }
 
 // OnChannelAdBreakBegin invokes when a user runs a midroll commercial break, either manually or automatically via ads manager.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelad_breakbegin.
-func (c *callback[Metadata]) OnChannelAdBreakBegin(onChannelAdBreakBegin Handler[ChannelAdBreakBeginEvent, Metadata]) {
-	c.onChannelAdBreakBegin = onChannelAdBreakBegin
+func (c *callback[Metadata]) OnChannelAdBreakBegin(onChannelAdBreakBegin Handler[ChannelAdBreakBeginEvent, Metadata]) func() {
+	return OnContext(c, onChannelAdBreakBegin.withContext())
+}
+
+// OnChannelAdBreakBeginContext is the same as OnChannelAdBreakBegin, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelAdBreakBeginContext(onChannelAdBreakBegin ContextHandler[ChannelAdBreakBeginEvent, Metadata]) func() {
+	return OnContext(c, onChannelAdBreakBegin)
 }
 
 // OnChannelChatClear invokes when a moderator or bot clears all messages from the chat room.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchatclear.
-func (c *callback[Metadata]) OnChannelChatClear(onChannelChatClear Handler[ChannelChatClearEvent, Metadata]) {
-	c.onChannelChatClear = onChannelChatClear
+func (c *callback[Metadata]) OnChannelChatClear(onChannelChatClear Handler[ChannelChatClearEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatClear.withContext())
+}
+
+// OnChannelChatClearContext is the same as OnChannelChatClear, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelChatClearContext(onChannelChatClear ContextHandler[ChannelChatClearEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatClear)
 }
 
 // OnChannelChatClearUserMessages invokes when a moderator or bot clears all messages for a specific user.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchatclear_user_messages.
-func (c *callback[Metadata]) OnChannelChatClearUserMessages(onChannelChatClearUserMessages Handler[ChannelChatClearUserMessagesEvent, Metadata]) {
-	c.onChannelChatClearUserMessages = onChannelChatClearUserMessages
+func (c *callback[Metadata]) OnChannelChatClearUserMessages(onChannelChatClearUserMessages Handler[ChannelChatClearUserMessagesEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatClearUserMessages.withContext())
+}
+
+// OnChannelChatClearUserMessagesContext is the same as OnChannelChatClearUserMessages, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelChatClearUserMessagesContext(onChannelChatClearUserMessages ContextHandler[ChannelChatClearUserMessagesEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatClearUserMessages)
 }
 
 // OnChannelChatMessage invokes when any user sends a message to a channel’s chat room.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchatmessage.
-func (c *callback[Metadata]) OnChannelChatMessage(onChannelChatMessage Handler[ChannelChatMessageEvent, Metadata]) {
-	c.onChannelChatMessage = onChannelChatMessage
+func (c *callback[Metadata]) OnChannelChatMessage(onChannelChatMessage Handler[ChannelChatMessageEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatMessage.withContext())
+}
+
+// OnChannelChatMessageContext is the same as OnChannelChatMessage, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelChatMessageContext(onChannelChatMessage ContextHandler[ChannelChatMessageEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatMessage)
 }
 
 // OnConduitShardDisabled invokes when any shard of conduit becomes disabled.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#conduitsharddisabled.
-func (c *callback[Metadata]) OnConduitShardDisabled(onConduitShardDisabled Handler[ConduitShardDisabledEvent, Metadata]) {
-	c.onConduitShardDisabled = onConduitShardDisabled
+func (c *callback[Metadata]) OnConduitShardDisabled(onConduitShardDisabled Handler[ConduitShardDisabledEvent, Metadata]) func() {
+	return OnContext(c, onConduitShardDisabled.withContext())
+}
+
+// OnConduitShardDisabledContext is the same as OnConduitShardDisabled, but handler receives context and returns error.
+func (c *callback[Metadata]) OnConduitShardDisabledContext(onConduitShardDisabled ContextHandler[ConduitShardDisabledEvent, Metadata]) func() {
+	return OnContext(c, onConduitShardDisabled)
 }
 
 // OnChannelBan invokes when a user is banned from a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelban.
-func (c *callback[Metadata]) OnChannelBan(onChannelBan Handler[ChannelBanEvent, Metadata]) {
-	c.onChannelBan = onChannelBan
+func (c *callback[Metadata]) OnChannelBan(onChannelBan Handler[ChannelBanEvent, Metadata]) func() {
+	return OnContext(c, onChannelBan.withContext())
+}
+
+// OnChannelBanContext is the same as OnChannelBan, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelBanContext(onChannelBan ContextHandler[ChannelBanEvent, Metadata]) func() {
+	return OnContext(c, onChannelBan)
 }
 
 // OnChannelUnban sends a notification when a viewer is unbanned from the specified channel.
 //
 // https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelunban.
-func (c *callback[Metadata]) OnChannelUnban(onChannelUnban Handler[ChannelUnbanEvent, Metadata]) {
-	c.onChannelUnban = onChannelUnban
+func (c *callback[Metadata]) OnChannelUnban(onChannelUnban Handler[ChannelUnbanEvent, Metadata]) func() {
+	return OnContext(c, onChannelUnban.withContext())
+}
+
+// OnChannelUnbanContext is the same as OnChannelUnban, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelUnbanContext(onChannelUnban ContextHandler[ChannelUnbanEvent, Metadata]) func() {
+	return OnContext(c, onChannelUnban)
 }
 
 // OnChannelChatNotification invokes when a user sends a chat notification to a channel’s chat room.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchatnotification.
-func (c *callback[Metadata]) OnChannelChatNotification(onChannelChatNotification Handler[ChannelChatNotificationEvent, Metadata]) {
-	c.onChannelChatNotification = onChannelChatNotification
+func (c *callback[Metadata]) OnChannelChatNotification(onChannelChatNotification Handler[ChannelChatNotificationEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatNotification.withContext())
+}
+
+// OnChannelChatNotificationContext is the same as OnChannelChatNotification, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelChatNotificationContext(onChannelChatNotification ContextHandler[ChannelChatNotificationEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatNotification)
 }
 
 // OnChannelModeratorAdd invokes when a user is added as a moderator to a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelmoderatoradd.
-func (c *callback[Metadata]) OnChannelModeratorAdd(onChannelModeratorAdd Handler[ChannelModeratorAddEvent, Metadata]) {
-	c.onChannelModeratorAdd = onChannelModeratorAdd
+func (c *callback[Metadata]) OnChannelModeratorAdd(onChannelModeratorAdd Handler[ChannelModeratorAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelModeratorAdd.withContext())
+}
+
+// OnChannelModeratorAddContext is the same as OnChannelModeratorAdd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelModeratorAddContext(onChannelModeratorAdd ContextHandler[ChannelModeratorAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelModeratorAdd)
 }
 
 // OnChannelModeratorRemove invokes when a user is removed as a moderator from a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelmoderatorremove.
-func (c *callback[Metadata]) OnChannelModeratorRemove(onChannelModeratorRemove Handler[ChannelModeratorRemoveEvent, Metadata]) {
-	c.onChannelModeratorRemove = onChannelModeratorRemove
+func (c *callback[Metadata]) OnChannelModeratorRemove(onChannelModeratorRemove Handler[ChannelModeratorRemoveEvent, Metadata]) func() {
+	return OnContext(c, onChannelModeratorRemove.withContext())
+}
+
+// OnChannelModeratorRemoveContext is the same as OnChannelModeratorRemove, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelModeratorRemoveContext(onChannelModeratorRemove ContextHandler[ChannelModeratorRemoveEvent, Metadata]) func() {
+	return OnContext(c, onChannelModeratorRemove)
 }
 
 // OnChannelPollBegin invokes when a broadcaster starts a poll in their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelpollbegin.
-func (c *callback[Metadata]) OnChannelPollBegin(onChannelPollBegin Handler[ChannelPollBeginEvent, Metadata]) {
-	c.onChannelPollBegin = onChannelPollBegin
+func (c *callback[Metadata]) OnChannelPollBegin(onChannelPollBegin Handler[ChannelPollBeginEvent, Metadata]) func() {
+	return OnContext(c, onChannelPollBegin.withContext())
+}
+
+// OnChannelPollBeginContext is the same as OnChannelPollBegin, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPollBeginContext(onChannelPollBegin ContextHandler[ChannelPollBeginEvent, Metadata]) func() {
+	return OnContext(c, onChannelPollBegin)
 }
 
 // OnChannelPollProgress invokes when a broadcaster updates a poll in their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelpollprogress.
-func (c *callback[Metadata]) OnChannelPollProgress(onChannelPollProgress Handler[ChannelPollProgressEvent, Metadata]) {
-	c.onChannelPollProgress = onChannelPollProgress
+func (c *callback[Metadata]) OnChannelPollProgress(onChannelPollProgress Handler[ChannelPollProgressEvent, Metadata]) func() {
+	return OnContext(c, onChannelPollProgress.withContext())
+}
+
+// OnChannelPollProgressContext is the same as OnChannelPollProgress, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPollProgressContext(onChannelPollProgress ContextHandler[ChannelPollProgressEvent, Metadata]) func() {
+	return OnContext(c, onChannelPollProgress)
 }
 
 // OnChannelPollEnd invokes when a broadcaster ends a poll in their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelpollend.
-func (c *callback[Metadata]) OnChannelPollEnd(onChannelPollEnd Handler[ChannelPollEndEvent, Metadata]) {
-	c.onChannelPollEnd = onChannelPollEnd
+func (c *callback[Metadata]) OnChannelPollEnd(onChannelPollEnd Handler[ChannelPollEndEvent, Metadata]) func() {
+	return OnContext(c, onChannelPollEnd.withContext())
+}
+
+// OnChannelPollEndContext is the same as OnChannelPollEnd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPollEndContext(onChannelPollEnd ContextHandler[ChannelPollEndEvent, Metadata]) func() {
+	return OnContext(c, onChannelPollEnd)
 }
 
 // OnChannelPredictionBegin invokes when a broadcaster starts a prediction in their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelpredictionbegin.
-func (c *callback[Metadata]) OnChannelPredictionBegin(onChannelPredictionBegin Handler[ChannelPredictionBeginEvent, Metadata]) {
-	c.onChannelPredictionBegin = onChannelPredictionBegin
+func (c *callback[Metadata]) OnChannelPredictionBegin(onChannelPredictionBegin Handler[ChannelPredictionBeginEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionBegin.withContext())
+}
+
+// OnChannelPredictionBeginContext is the same as OnChannelPredictionBegin, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPredictionBeginContext(onChannelPredictionBegin ContextHandler[ChannelPredictionBeginEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionBegin)
 }
 
 // OnChannelPredictionProgress invokes when a broadcaster updates a prediction in their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelpredictionprogress.
-func (c *callback[Metadata]) OnChannelPredictionProgress(onChannelPredictionProgress Handler[ChannelPredictionProgressEvent, Metadata]) {
-	c.onChannelPredictionProgress = onChannelPredictionProgress
+func (c *callback[Metadata]) OnChannelPredictionProgress(onChannelPredictionProgress Handler[ChannelPredictionProgressEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionProgress.withContext())
+}
+
+// OnChannelPredictionProgressContext is the same as OnChannelPredictionProgress, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPredictionProgressContext(onChannelPredictionProgress ContextHandler[ChannelPredictionProgressEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionProgress)
 }
 
 // OnChannelPredictionLock invokes when a broadcaster locks a prediction in their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelpredictionlock.
-func (c *callback[Metadata]) OnChannelPredictionLock(onChannelPredictionLock Handler[ChannelPredictionLockEvent, Metadata]) {
-	c.onChannelPredictionLock = onChannelPredictionLock
+func (c *callback[Metadata]) OnChannelPredictionLock(onChannelPredictionLock Handler[ChannelPredictionLockEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionLock.withContext())
+}
+
+// OnChannelPredictionLockContext is the same as OnChannelPredictionLock, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPredictionLockContext(onChannelPredictionLock ContextHandler[ChannelPredictionLockEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionLock)
 }
 
 // OnChannelPredictionEnd invokes when a broadcaster ends a prediction in their channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelpredictionend.
-func (c *callback[Metadata]) OnChannelPredictionEnd(onChannelPredictionEnd Handler[ChannelPredictionEndEvent, Metadata]) {
-	c.onChannelPredictionEnd = onChannelPredictionEnd
+func (c *callback[Metadata]) OnChannelPredictionEnd(onChannelPredictionEnd Handler[ChannelPredictionEndEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionEnd.withContext())
+}
+
+// OnChannelPredictionEndContext is the same as OnChannelPredictionEnd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPredictionEndContext(onChannelPredictionEnd ContextHandler[ChannelPredictionEndEvent, Metadata]) func() {
+	return OnContext(c, onChannelPredictionEnd)
 }
 
 // OnChannelRaid invokes when a channel raids another channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelraid.
-func (c *callback[Metadata]) OnChannelRaid(onChannelRaid Handler[ChannelRaidEvent, Metadata]) {
-	c.onChannelRaid = onChannelRaid
+func (c *callback[Metadata]) OnChannelRaid(onChannelRaid Handler[ChannelRaidEvent, Metadata]) func() {
+	return OnContext(c, onChannelRaid.withContext())
+}
+
+// OnChannelRaidContext is the same as OnChannelRaid, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelRaidContext(onChannelRaid ContextHandler[ChannelRaidEvent, Metadata]) func() {
+	return OnContext(c, onChannelRaid)
 }
 
 // OnChannelPointsCustomRewardRedemptionAdd invokes when a user redeems a custom channel points reward.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchannel_points_custom_reward_redemptionadd.
-func (c *callback[Metadata]) OnChannelPointsCustomRewardRedemptionAdd(onChannelPointsCustomRewardRedemptionAdd Handler[ChannelPointsCustomRewardRedemptionAddEvent, Metadata]) {
-	c.onChannelPointsCustomRewardRedemptionAdd = onChannelPointsCustomRewardRedemptionAdd
+func (c *callback[Metadata]) OnChannelPointsCustomRewardRedemptionAdd(onChannelPointsCustomRewardRedemptionAdd Handler[ChannelPointsCustomRewardRedemptionAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardRedemptionAdd.withContext())
+}
+
+// OnChannelPointsCustomRewardRedemptionAddContext is the same as OnChannelPointsCustomRewardRedemptionAdd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPointsCustomRewardRedemptionAddContext(onChannelPointsCustomRewardRedemptionAdd ContextHandler[ChannelPointsCustomRewardRedemptionAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardRedemptionAdd)
 }
 
 // OnChannelPointsCustomRewardRedemptionUpdate invokes when a user updates a custom channel points reward redemption.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchannel_points_custom_reward_redemptionupdate.
-func (c *callback[Metadata]) OnChannelPointsCustomRewardRedemptionUpdate(onChannelPointsCustomRewardRedemptionUpdate Handler[ChannelPointsCustomRewardRedemptionUpdateEvent, Metadata]) {
-	c.onChannelPointsCustomRewardRedemptionUpdate = onChannelPointsCustomRewardRedemptionUpdate
+func (c *callback[Metadata]) OnChannelPointsCustomRewardRedemptionUpdate(onChannelPointsCustomRewardRedemptionUpdate Handler[ChannelPointsCustomRewardRedemptionUpdateEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardRedemptionUpdate.withContext())
+}
+
+// OnChannelPointsCustomRewardRedemptionUpdateContext is the same as OnChannelPointsCustomRewardRedemptionUpdate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPointsCustomRewardRedemptionUpdateContext(onChannelPointsCustomRewardRedemptionUpdate ContextHandler[ChannelPointsCustomRewardRedemptionUpdateEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardRedemptionUpdate)
 }
 
 // OnChannelPointsAutomaticRewardRedemptionAdd invokes when a user redeems an automatic channel points reward.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchannel_points_automatic_reward_redemptionadd.
-func (c *callback[Metadata]) OnChannelPointsAutomaticRewardRedemptionAdd(onChannelPointsAutomaticRewardRedemptionAdd Handler[ChannelPointsAutomaticRewardRedemptionAddEvent, Metadata]) {
-	c.onChannelPointsAutomaticRewardRedemptionAdd = onChannelPointsAutomaticRewardRedemptionAdd
+func (c *callback[Metadata]) OnChannelPointsAutomaticRewardRedemptionAdd(onChannelPointsAutomaticRewardRedemptionAdd Handler[ChannelPointsAutomaticRewardRedemptionAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsAutomaticRewardRedemptionAdd.withContext())
+}
+
+// OnChannelPointsAutomaticRewardRedemptionAddContext is the same as OnChannelPointsAutomaticRewardRedemptionAdd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPointsAutomaticRewardRedemptionAddContext(onChannelPointsAutomaticRewardRedemptionAdd ContextHandler[ChannelPointsAutomaticRewardRedemptionAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsAutomaticRewardRedemptionAdd)
 }
 
 // OnChannelPointsAutomaticRewardRedemptionAddV2 invokes when a user redeems an automatic channel points reward.
 // Only public rewards trigger notifications, not private ones.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchannel_points_automatic_reward_redemptionadd-v2.
-func (c *callback[Metadata]) OnChannelPointsAutomaticRewardRedemptionAddV2(onChannelPointsAutomaticRewardRedemptionAddV2 Handler[ChannelPointsAutomaticRewardRedemptionAddEventV2, Metadata]) {
-	c.onChannelPointsAutomaticRewardRedemptionAddV2 = onChannelPointsAutomaticRewardRedemptionAddV2
+func (c *callback[Metadata]) OnChannelPointsAutomaticRewardRedemptionAddV2(onChannelPointsAutomaticRewardRedemptionAddV2 Handler[ChannelPointsAutomaticRewardRedemptionAddEventV2, Metadata]) func() {
+	return OnContext(c, onChannelPointsAutomaticRewardRedemptionAddV2.withContext())
+}
+
+// OnChannelPointsAutomaticRewardRedemptionAddV2Context is the same as OnChannelPointsAutomaticRewardRedemptionAddV2, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPointsAutomaticRewardRedemptionAddV2Context(onChannelPointsAutomaticRewardRedemptionAddV2 ContextHandler[ChannelPointsAutomaticRewardRedemptionAddEventV2, Metadata]) func() {
+	return OnContext(c, onChannelPointsAutomaticRewardRedemptionAddV2)
 }
 
 // OnChannelPointsCustomRewardAdd invokes when a broadcaster adds a custom channel points reward.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchannel_points_rewardadd.
-func (c *callback[Metadata]) OnChannelPointsCustomRewardAdd(onChannelPointsCustomRewardAdd Handler[ChannelPointsCustomRewardAddEvent, Metadata]) {
-	c.onChannelPointsCustomRewardAdd = onChannelPointsCustomRewardAdd
+func (c *callback[Metadata]) OnChannelPointsCustomRewardAdd(onChannelPointsCustomRewardAdd Handler[ChannelPointsCustomRewardAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardAdd.withContext())
+}
+
+// OnChannelPointsCustomRewardAddContext is the same as OnChannelPointsCustomRewardAdd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPointsCustomRewardAddContext(onChannelPointsCustomRewardAdd ContextHandler[ChannelPointsCustomRewardAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardAdd)
 }
 
 // OnChannelPointsCustomRewardUpdate invokes when a broadcaster updates a custom channel points reward.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchannel_points_rewardupdate.
-func (c *callback[Metadata]) OnChannelPointsCustomRewardUpdate(onChannelPointsCustomRewardUpdate Handler[ChannelPointsCustomRewardUpdateEvent, Metadata]) {
-	c.onChannelPointsCustomRewardUpdate = onChannelPointsCustomRewardUpdate
+func (c *callback[Metadata]) OnChannelPointsCustomRewardUpdate(onChannelPointsCustomRewardUpdate Handler[ChannelPointsCustomRewardUpdateEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardUpdate.withContext())
+}
+
+// OnChannelPointsCustomRewardUpdateContext is the same as OnChannelPointsCustomRewardUpdate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPointsCustomRewardUpdateContext(onChannelPointsCustomRewardUpdate ContextHandler[ChannelPointsCustomRewardUpdateEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardUpdate)
 }
 
 // OnChannelPointsCustomRewardRemove invokes when a broadcaster removes a custom channel points reward.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchannel_points_rewardremove.
-func (c *callback[Metadata]) OnChannelPointsCustomRewardRemove(onChannelPointsCustomRewardRemove Handler[ChannelPointsCustomRewardRemoveEvent, Metadata]) {
-	c.onChannelPointsCustomRewardRemove = onChannelPointsCustomRewardRemove
+func (c *callback[Metadata]) OnChannelPointsCustomRewardRemove(onChannelPointsCustomRewardRemove Handler[ChannelPointsCustomRewardRemoveEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardRemove.withContext())
+}
+
+// OnChannelPointsCustomRewardRemoveContext is the same as OnChannelPointsCustomRewardRemove, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelPointsCustomRewardRemoveContext(onChannelPointsCustomRewardRemove ContextHandler[ChannelPointsCustomRewardRemoveEvent, Metadata]) func() {
+	return OnContext(c, onChannelPointsCustomRewardRemove)
 }
 
 // OnStreamOffline invokes when a channel goes offline.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#streamoffline.
-func (c *callback[Metadata]) OnStreamOffline(onStreamOffline Handler[StreamOfflineEvent, Metadata]) {
-	c.onStreamOffline = onStreamOffline
+func (c *callback[Metadata]) OnStreamOffline(onStreamOffline Handler[StreamOfflineEvent, Metadata]) func() {
+	return OnContext(c, onStreamOffline.withContext())
+}
+
+// OnStreamOfflineContext is the same as OnStreamOffline, but handler receives context and returns error.
+func (c *callback[Metadata]) OnStreamOfflineContext(onStreamOffline ContextHandler[StreamOfflineEvent, Metadata]) func() {
+	return OnContext(c, onStreamOffline)
 }
 
 // OnStreamOnline invokes when a channel goes online.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#streamonline.
-func (c *callback[Metadata]) OnStreamOnline(onStreamOnline Handler[StreamOnlineEvent, Metadata]) {
-	c.onStreamOnline = onStreamOnline
+func (c *callback[Metadata]) OnStreamOnline(onStreamOnline Handler[StreamOnlineEvent, Metadata]) func() {
+	return OnContext(c, onStreamOnline.withContext())
+}
+
+// OnStreamOnlineContext is the same as OnStreamOnline, but handler receives context and returns error.
+func (c *callback[Metadata]) OnStreamOnlineContext(onStreamOnline ContextHandler[StreamOnlineEvent, Metadata]) func() {
+	return OnContext(c, onStreamOnline)
 }
 
 // OnChannelSubscribe invokes when a user subscribes to a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelsubscribe.
-func (c *callback[Metadata]) OnChannelSubscribe(onChannelSubscribe Handler[ChannelSubscribeEvent, Metadata]) {
-	c.onChannelSubscribe = onChannelSubscribe
+func (c *callback[Metadata]) OnChannelSubscribe(onChannelSubscribe Handler[ChannelSubscribeEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscribe.withContext())
+}
+
+// OnChannelSubscribeContext is the same as OnChannelSubscribe, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelSubscribeContext(onChannelSubscribe ContextHandler[ChannelSubscribeEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscribe)
 }
 
 // OnChannelSubscriptionEnd invokes when a user’s subscription to a channel ends.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelsubscriptionend.
-func (c *callback[Metadata]) OnChannelSubscriptionEnd(onChannelSubscriptionEnd Handler[ChannelSubscriptionEndEvent, Metadata]) {
-	c.onChannelSubscriptionEnd = onChannelSubscriptionEnd
+func (c *callback[Metadata]) OnChannelSubscriptionEnd(onChannelSubscriptionEnd Handler[ChannelSubscriptionEndEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscriptionEnd.withContext())
+}
+
+// OnChannelSubscriptionEndContext is the same as OnChannelSubscriptionEnd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelSubscriptionEndContext(onChannelSubscriptionEnd ContextHandler[ChannelSubscriptionEndEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscriptionEnd)
 }
 
 // OnChannelSubscriptionMessage invokes when a user sends a subscription message to a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelsubscriptionmessage.
-func (c *callback[Metadata]) OnChannelSubscriptionMessage(onChannelSubscriptionMessage Handler[ChannelSubscriptionMessageEvent, Metadata]) {
-	c.onChannelSubscriptionMessage = onChannelSubscriptionMessage
+func (c *callback[Metadata]) OnChannelSubscriptionMessage(onChannelSubscriptionMessage Handler[ChannelSubscriptionMessageEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscriptionMessage.withContext())
+}
+
+// OnChannelSubscriptionMessageContext is the same as OnChannelSubscriptionMessage, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelSubscriptionMessageContext(onChannelSubscriptionMessage ContextHandler[ChannelSubscriptionMessageEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscriptionMessage)
 }
 
 // OnChannelSubscriptionGift invokes when a user gifts a subscription to a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelsubscriptiongift.
-func (c *callback[Metadata]) OnChannelSubscriptionGift(onChannelSubscriptionGift Handler[ChannelSubscriptionGiftEvent, Metadata]) {
-	c.onChannelSubscriptionGift = onChannelSubscriptionGift
+func (c *callback[Metadata]) OnChannelSubscriptionGift(onChannelSubscriptionGift Handler[ChannelSubscriptionGiftEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscriptionGift.withContext())
+}
+
+// OnChannelSubscriptionGiftContext is the same as OnChannelSubscriptionGift, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelSubscriptionGiftContext(onChannelSubscriptionGift ContextHandler[ChannelSubscriptionGiftEvent, Metadata]) func() {
+	return OnContext(c, onChannelSubscriptionGift)
 }
 
 // OnChannelUnbanRequestCreate invokes when a user creates an unban request for a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelunbanrequestcreate.
-func (c *callback[Metadata]) OnChannelUnbanRequestCreate(onChannelUnbanRequestCreate Handler[ChannelUnbanRequestCreateEvent, Metadata]) {
-	c.onChannelUnbanRequestCreate = onChannelUnbanRequestCreate
+func (c *callback[Metadata]) OnChannelUnbanRequestCreate(onChannelUnbanRequestCreate Handler[ChannelUnbanRequestCreateEvent, Metadata]) func() {
+	return OnContext(c, onChannelUnbanRequestCreate.withContext())
+}
+
+// OnChannelUnbanRequestCreateContext is the same as OnChannelUnbanRequestCreate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelUnbanRequestCreateContext(onChannelUnbanRequestCreate ContextHandler[ChannelUnbanRequestCreateEvent, Metadata]) func() {
+	return OnContext(c, onChannelUnbanRequestCreate)
 }
 
 // OnChannelUnbanRequestResolve invokes when a user resolves an unban request for a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelunbanrequestresolve.
-func (c *callback[Metadata]) OnChannelUnbanRequestResolve(onChannelUnbanRequestResolve Handler[ChannelUnbanRequestResolveEvent, Metadata]) {
-	c.onChannelUnbanRequestResolve = onChannelUnbanRequestResolve
+func (c *callback[Metadata]) OnChannelUnbanRequestResolve(onChannelUnbanRequestResolve Handler[ChannelUnbanRequestResolveEvent, Metadata]) func() {
+	return OnContext(c, onChannelUnbanRequestResolve.withContext())
+}
+
+// OnChannelUnbanRequestResolveContext is the same as OnChannelUnbanRequestResolve, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelUnbanRequestResolveContext(onChannelUnbanRequestResolve ContextHandler[ChannelUnbanRequestResolveEvent, Metadata]) func() {
+	return OnContext(c, onChannelUnbanRequestResolve)
 }
 
 // OnUserUpdate invokes when a user updates their profile information.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#userupdate.
-func (c *callback[Metadata]) OnUserUpdate(onUserUpdate Handler[UserUpdateEvent, Metadata]) {
-	c.onUserUpdate = onUserUpdate
+func (c *callback[Metadata]) OnUserUpdate(onUserUpdate Handler[UserUpdateEvent, Metadata]) func() {
+	return OnContext(c, onUserUpdate.withContext())
+}
+
+// OnUserUpdateContext is the same as OnUserUpdate, but handler receives context and returns error.
+func (c *callback[Metadata]) OnUserUpdateContext(onUserUpdate ContextHandler[UserUpdateEvent, Metadata]) func() {
+	return OnContext(c, onUserUpdate)
 }
 
 // OnChannelVipAdd invokes when a user is added as a VIP to a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelvipadd.
-func (c *callback[Metadata]) OnChannelVipAdd(onChannelVipAdd Handler[ChannelVipAddEvent, Metadata]) {
-	c.onChannelVipAdd = onChannelVipAdd
+func (c *callback[Metadata]) OnChannelVipAdd(onChannelVipAdd Handler[ChannelVipAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelVipAdd.withContext())
+}
+
+// OnChannelVipAddContext is the same as OnChannelVipAdd, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelVipAddContext(onChannelVipAdd ContextHandler[ChannelVipAddEvent, Metadata]) func() {
+	return OnContext(c, onChannelVipAdd)
 }
 
 // OnChannelVipRemove invokes when a user is removed as a VIP from a channel.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelvipremove.
-func (c *callback[Metadata]) OnChannelVipRemove(onChannelVipRemove Handler[ChannelVipRemoveEvent, Metadata]) {
-	c.onChannelVipRemove = onChannelVipRemove
+func (c *callback[Metadata]) OnChannelVipRemove(onChannelVipRemove Handler[ChannelVipRemoveEvent, Metadata]) func() {
+	return OnContext(c, onChannelVipRemove.withContext())
+}
+
+// OnChannelVipRemoveContext is the same as OnChannelVipRemove, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelVipRemoveContext(onChannelVipRemove ContextHandler[ChannelVipRemoveEvent, Metadata]) func() {
+	return OnContext(c, onChannelVipRemove)
 }
 
 // OnChannelChatMessageDelete invokes when a user deletes a message in a channel's chat room.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#channelchatmessage_delete.
-func (c *callback[Metadata]) OnChannelChatMessageDelete(onChannelChatMessageDelete Handler[ChannelChatMessageDeleteEvent, Metadata]) {
-	c.onChannelChatMessageDelete = onChannelChatMessageDelete
+func (c *callback[Metadata]) OnChannelChatMessageDelete(onChannelChatMessageDelete Handler[ChannelChatMessageDeleteEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatMessageDelete.withContext())
+}
+
+// OnChannelChatMessageDeleteContext is the same as OnChannelChatMessageDelete, but handler receives context and returns error.
+func (c *callback[Metadata]) OnChannelChatMessageDeleteContext(onChannelChatMessageDelete ContextHandler[ChannelChatMessageDeleteEvent, Metadata]) func() {
+	return OnContext(c, onChannelChatMessageDelete)
 }
 
 // OnUserAuthorizationRevoke invokes when a user revokes authorization for an application.
@@ -400,6 +687,11 @@ func (c *callback[Metadata]) OnChannelChatMessageDelete(onChannelChatMessageDele
 // Note: This subscription type is only supported by webhooks, and cannot be used with websockets.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/#userauthorizationrevoke.
-func (c *callback[Metadata]) OnUserAuthorizationRevoke(onUserAuthorizationRevoke Handler[UserAuthorizationRevokeEvent, Metadata]) {
-	c.onUserAuthorizationRevoke = onUserAuthorizationRevoke
+func (c *callback[Metadata]) OnUserAuthorizationRevoke(onUserAuthorizationRevoke Handler[UserAuthorizationRevokeEvent, Metadata]) func() {
+	return OnContext(c, onUserAuthorizationRevoke.withContext())
+}
+
+// OnUserAuthorizationRevokeContext is the same as OnUserAuthorizationRevoke, but handler receives context and returns error.
+func (c *callback[Metadata]) OnUserAuthorizationRevokeContext(onUserAuthorizationRevoke ContextHandler[UserAuthorizationRevokeEvent, Metadata]) func() {
+	return OnContext(c, onUserAuthorizationRevoke)
 }
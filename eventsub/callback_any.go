@@ -0,0 +1,82 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// eventsBufferSize is a size of the channel returned by Events.
+const eventsBufferSize = 64
+
+// ErrEventsOverflow indicates that event is dropped, because reader of the Events channel falls behind and the
+// channel buffer is full.
+var ErrEventsOverflow = errors.New("events channel is full")
+
+// OnAny invokes for every decoded event that implements Event, in addition to handlers of the specific event. It's
+// not invoked for undefined events (see OnUndefinedEvent).
+func (c *callback[Metadata]) OnAny(onAny Handler[Event, Metadata]) func() {
+	return c.OnAnyContext(onAny.withContext())
+}
+
+// OnAnyContext is the same as OnAny, but handler receives context and returns error.
+func (c *callback[Metadata]) OnAnyContext(onAny ContextHandler[Event, Metadata]) func() {
+	if onAny == nil {
+		return func() {}
+	}
+
+	return c.onAny.add(func(ctx context.Context, envelope Envelope, metadata Metadata) error {
+		return onAny(ctx, envelope.Event.(Event), metadata)
+	})
+}
+
+// Events returns channel that receives every event that OnAny receives, as an alternative to callbacks. Event field
+// of the Envelope implements Event, and Metadata field is of the client's metadata type.
+//
+// Channel is closed when the context is done. Sending to the channel never blocks the client: once channel buffer of
+// 64 events is full, new events are dropped and ErrEventsOverflow is reported to OnHandlerError callback until the
+// reader catches up.
+func (c *callback[Metadata]) Events(ctx context.Context) <-chan Envelope {
+	events := make(chan Envelope, eventsBufferSize)
+
+	var (
+		// mu guards closing of the channel, so events are never sent to the closed channel.
+		mu     sync.RWMutex
+		closed bool
+	)
+
+	remove := c.onAny.add(func(_ context.Context, envelope Envelope, metadata Metadata) error {
+		mu.RLock()
+		defer mu.RUnlock()
+
+		if closed {
+			return nil
+		}
+
+		select {
+		case events <- envelope:
+		default:
+			// Overflow is not returned, so synchronous Webhook doesn't make Twitch redeliver the event to every handler.
+			if onHandlerError := c.getOnHandlerError(); onHandlerError != nil {
+				onHandlerError(fmt.Errorf("%s v%s: %w", envelope.Type, envelope.Version, ErrEventsOverflow), metadata)
+			}
+		}
+
+		return nil
+	})
+
+	go func() {
+		<-ctx.Done()
+
+		remove()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		closed = true
+		close(events)
+	}()
+
+	return events
+}
@@ -0,0 +1,92 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOnAnyReceivesVersionOfPayload(t *testing.T) {
+	c := &callback[struct{}]{synchronous: true}
+
+	var versions []string
+
+	c.OnAny(func(event Event, _ struct{}) {
+		versions = append(versions, event.Version())
+	})
+
+	rawEvent := RawEvent{Event: []byte(`{"broadcaster_user_id":"1"}`)}
+
+	for _, version := range []string{"1", "2"} {
+		if err := c.runEventCallback(context.Background(), EventTypeChannelUpdate, version, rawEvent, struct{}{}); err != nil {
+			t.Fatalf("run callback of v%s: %v", version, err)
+		}
+	}
+
+	if len(versions) != 2 || versions[0] != "1" || versions[1] != "2" {
+		t.Fatalf("expected versions [1 2], got %v", versions)
+	}
+}
+
+func TestOnAnyReceivesVersionOfFallbackPayload(t *testing.T) {
+	c := &callback[struct{}]{synchronous: true}
+
+	var versions []string
+
+	c.OnAny(func(event Event, _ struct{}) {
+		versions = append(versions, event.Version())
+	})
+
+	rawEvent := RawEvent{Event: []byte(`{"user_id":"1"}`)}
+
+	if err := c.runEventCallback(context.Background(), EventTypeChannelFollow, "3", rawEvent, struct{}{}); err != nil {
+		t.Fatalf("run callback: %v", err)
+	}
+
+	if len(versions) != 1 || versions[0] != "3" {
+		t.Fatalf("expected version 3 of unregistered payload, got %v", versions)
+	}
+
+	if version := (ChannelFollowEvent{}).Version(); version != "2" {
+		t.Fatalf("expected registered version 2 of the event that is not decoded, got %s", version)
+	}
+}
+
+func TestEventsDropsEventsWhenBufferIsFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &callback[struct{}]{synchronous: true}
+
+	var overflows int
+
+	c.OnHandlerError(func(err error, _ struct{}) {
+		if !errors.Is(err, ErrEventsOverflow) {
+			t.Errorf("expected ErrEventsOverflow, got %v", err)
+		}
+
+		overflows++
+	})
+
+	events := c.Events(ctx)
+	rawEvent := RawEvent{Event: []byte(`{"user_id":"1"}`)}
+
+	for range eventsBufferSize + 2 {
+		if err := c.runEventCallback(ctx, EventTypeChannelBan, "1", rawEvent, struct{}{}); err != nil {
+			t.Fatalf("run callback: %v", err)
+		}
+	}
+
+	if overflows != 2 {
+		t.Fatalf("expected 2 overflows, got %d", overflows)
+	}
+
+	if len(events) != eventsBufferSize {
+		t.Fatalf("expected %d buffered events, got %d", eventsBufferSize, len(events))
+	}
+
+	cancel()
+
+	for range events {
+	}
+}
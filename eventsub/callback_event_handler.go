@@ -1,10 +1,9 @@
 package eventsub
 
 import (
+	"context"
 	"errors"
 	"fmt"
-
-	"github.com/twirapp/twitchy/internal/json"
 )
 
 // ErrUndefinedEventType indicates that eventsub sent an event that is not defined in the library, and the user has not
@@ -17,151 +16,137 @@ type RawEvent struct {
 	Event        []byte
 }
 
-// runEventCallback runs an event callback from the callback store if it is set by the user, or skips this run without
-// error otherwise.
+// runEventCallback parses event payload to its Go type from the event registry and runs each handler registered by the
+// user for the event and then OnAny handlers, or skips this run without error if there are no handlers.
 //
 // If the provided EventType is not defined in the library, ErrUndefinedEventType will be returned or OnUndefinedEvent
-// will be triggered, if it is set by the user.
+// will be triggered, if it is set by the user. OnUndefinedEvent is also triggered for versions of defined event types
+// that are not registered.
+//
+// Event is parsed once and shared between handlers, so handlers must not modify its reference fields (e.g. slices).
 func (c *callback[Metadata]) runEventCallback(
+	ctx context.Context,
 	eventType EventType,
 	eventVersion string,
 	rawEvent RawEvent,
 	metadata Metadata,
 ) error {
-	event := rawEvent.Event
-
-	switch eventType {
-	case EventTypeAutomodMessageHold:
-		switch eventVersion {
-		case "1":
-			return runEventCallbackHandler(c.onAutomodMessageHold, event, metadata)
-		case "2":
-			return runEventCallbackHandler(c.onAutomodMessageHoldV2, event, metadata)
-		}
-	case EventTypeAutomodMessageUpdate:
-		switch eventVersion {
-		case "1":
-			return runEventCallbackHandler(c.onAutomodMessageUpdate, event, metadata)
-		case "2":
-			return runEventCallbackHandler(c.onAutomodMessageUpdateV2, event, metadata)
-		}
-	case EventTypeAutomodSettingsUpdate:
-		return runEventCallbackHandler(c.onAutomodSettingsUpdate, event, metadata)
-	case EventTypeAutomodTermsUpdate:
-		return runEventCallbackHandler(c.onAutomodTermsUpdate, event, metadata)
-	case EventTypeChannelBitsUse:
-		return runEventCallbackHandler(c.onChannelBitsUse, event, metadata)
-	case EventTypeChannelUpdate:
-		return runEventCallbackHandler(c.onChannelUpdate, event, metadata)
-	case EventTypeChannelFollow:
-		return runEventCallbackHandler(c.onChannelFollow, event, metadata)
-	case EventTypeChannelAdBreakBegin:
-		return runEventCallbackHandler(c.onChannelAdBreakBegin, event, metadata)
-	case EventTypeChannelChatClear:
-		return runEventCallbackHandler(c.onChannelChatClear, event, metadata)
-	case EventTypeChannelChatClearUserMessages:
-		return runEventCallbackHandler(c.onChannelChatClearUserMessages, event, metadata)
-	case EventTypeChannelChatMessage:
-		return runEventCallbackHandler(c.onChannelChatMessage, event, metadata)
-	case EventTypeConduitShardDisabled:
-		return runEventCallbackHandler(c.onConduitShardDisabled, event, metadata)
-	case EventTypeChannelBan:
-		return runEventCallbackHandler(c.onChannelBan, event, metadata)
-	case EventTypeChannelUnban:
-		return runEventCallbackHandler(c.onChannelUnban, event, metadata)
-	case EventTypeChannelChatNotification:
-		return runEventCallbackHandler(c.onChannelChatNotification, event, metadata)
-	case EventTypeChannelModeratorAdd:
-		return runEventCallbackHandler(c.onChannelModeratorAdd, event, metadata)
-	case EventTypeChannelModeratorRemove:
-		return runEventCallbackHandler(c.onChannelModeratorRemove, event, metadata)
-	case EventTypeChannelPollBegin:
-		return runEventCallbackHandler(c.onChannelPollBegin, event, metadata)
-	case EventTypeChannelPollProgress:
-		return runEventCallbackHandler(c.onChannelPollProgress, event, metadata)
-	case EventTypeChannelPollEnd:
-		return runEventCallbackHandler(c.onChannelPollEnd, event, metadata)
-	case EventTypeChannelPredictionBegin:
-		return runEventCallbackHandler(c.onChannelPredictionBegin, event, metadata)
-	case EventTypeChannelPredictionProgress:
-		return runEventCallbackHandler(c.onChannelPredictionProgress, event, metadata)
-	case EventTypeChannelPredictionLock:
-		return runEventCallbackHandler(c.onChannelPredictionLock, event, metadata)
-	case EventTypeChannelPredictionEnd:
-		return runEventCallbackHandler(c.onChannelPredictionEnd, event, metadata)
-	case EventTypeChannelRaid:
-		return runEventCallbackHandler(c.onChannelRaid, event, metadata)
-	case EventTypeChannelPointsCustomRewardRedemptionAdd:
-		return runEventCallbackHandler(c.onChannelPointsCustomRewardRedemptionAdd, event, metadata)
-	case EventTypeChannelPointsCustomRewardRedemptionUpdate:
-		return runEventCallbackHandler(c.onChannelPointsCustomRewardRedemptionUpdate, event, metadata)
-	case EventTypeChannelPointsAutomaticRewardRedemptionAdd:
-		switch eventVersion {
-		case "1":
-			return runEventCallbackHandler(c.onChannelPointsAutomaticRewardRedemptionAdd, event, metadata)
-		case "2":
-			return runEventCallbackHandler(c.onChannelPointsAutomaticRewardRedemptionAddV2, event, metadata)
-		}
-	case EventTypeUserAuthorizationRevoke:
-		return runEventCallbackHandler(c.onUserAuthorizationRevoke, event, metadata)
-	case EventTypeChannelPointsRewardAdd:
-		return runEventCallbackHandler(c.onChannelPointsCustomRewardAdd, event, metadata)
-	case EventTypeChannelPointsRewardUpdate:
-		return runEventCallbackHandler(c.onChannelPointsCustomRewardUpdate, event, metadata)
-	case EventTypeChannelPointsRewardRemove:
-		return runEventCallbackHandler(c.onChannelPointsCustomRewardRemove, event, metadata)
-	case EventTypeStreamOffline:
-		return runEventCallbackHandler(c.onStreamOffline, event, metadata)
-	case EventTypeStreamOnline:
-		return runEventCallbackHandler(c.onStreamOnline, event, metadata)
-	case EventTypeChannelSubscribe:
-		return runEventCallbackHandler(c.onChannelSubscribe, event, metadata)
-	case EventTypeChannelSubscriptionEnd:
-		return runEventCallbackHandler(c.onChannelSubscriptionEnd, event, metadata)
-	case EventTypeChannelSubscriptionMessage:
-		return runEventCallbackHandler(c.onChannelSubscriptionMessage, event, metadata)
-	case EventTypeChannelSubscriptionGift:
-		return runEventCallbackHandler(c.onChannelSubscriptionGift, event, metadata)
-	case EventTypeChannelUnbanRequestCreate:
-		return runEventCallbackHandler(c.onChannelUnbanRequestCreate, event, metadata)
-	case EventTypeChannelUnbanRequestResolve:
-		return runEventCallbackHandler(c.onChannelUnbanRequestResolve, event, metadata)
-	case EventTypeUserUpdate:
-		return runEventCallbackHandler(c.onUserUpdate, event, metadata)
-	case EventTypeChannelVipAdd:
-		return runEventCallbackHandler(c.onChannelVipAdd, event, metadata)
-	case EventTypeChannelVipRemove:
-		return runEventCallbackHandler(c.onChannelVipRemove, event, metadata)
-	case EventTypeChannelMessageDelete:
-		return runEventCallbackHandler(c.onChannelChatMessageDelete, event, metadata)
-	default:
-		if c.onUndefinedEvent != nil {
-			go c.onUndefinedEvent(rawEvent, metadata)
-			return nil
+	key := eventKey{
+		eventType: eventType,
+		version:   eventVersion,
+	}
+
+	envelope := Envelope{
+		Type:     eventType,
+		Version:  eventVersion,
+		Metadata: metadata,
+	}
+
+	descriptor, ok := registry.lookup(key)
+	if !ok {
+		if onUndefinedEvent := c.getOnUndefinedEvent(); onUndefinedEvent != nil {
+			envelope.Event = rawEvent
+
+			return c.invoke(ctx, envelope, metadata, func(context.Context) error {
+				onUndefinedEvent(rawEvent, metadata)
+				return nil
+			})
 		}
 
 		return ErrUndefinedEventType
 	}
 
-	return nil
+	var entries []handlerEntry[any, Metadata]
+
+	if eventHandlers := c.handlersOf(descriptor.goType); eventHandlers != nil {
+		entries = eventHandlers.list()
+	}
+
+	anyEntries := c.onAny.list()
+	if len(entries) == 0 && len(anyEntries) == 0 {
+		return nil
+	}
+
+	event, err := descriptor.decode(rawEvent.Event, eventVersion)
+	if err != nil {
+		return fmt.Errorf("unmarshal event payload: %w", err)
+	}
+
+	envelope.Event = event
+
+	// Events registered by the user with RegisterEvent may not implement Event.
+	if _, ok := event.(Event); !ok {
+		anyEntries = nil
+	}
+
+	handlers := make([]func(context.Context) error, 0, len(entries)+len(anyEntries))
+
+	for _, entry := range entries {
+		handler := entry.handler
+
+		handlers = append(handlers, func(ctx context.Context) error {
+			return handler(ctx, event, metadata)
+		})
+	}
+
+	for _, entry := range anyEntries {
+		handler := entry.handler
+
+		handlers = append(handlers, func(ctx context.Context) error {
+			return handler(ctx, envelope, metadata)
+		})
+	}
+
+	return c.invoke(ctx, envelope, metadata, handlers...)
 }
 
-// runEventCallbackHandler parses provided payload as JSON data to generic event and runs handler in separate go-routine
-// with this event and metadata if handler is defined by user, otherwise returns without error.
-func runEventCallbackHandler[Event, Metadata any](
-	handler Handler[Event, Metadata],
-	eventPayload []byte,
-	eventMetadata Metadata,
+// invoke runs handlers of the message wrapped with middlewares synchronously and returns their joined errors if
+// callback store is synchronous, otherwise dispatches handlers and reports their errors to OnHandlerError callback.
+// Handlers are run all or nothing: ErrShuttingDown is returned without running any of them if client is shutting
+// down, so the message can be redelivered without running some handlers twice.
+func (c *callback[Metadata]) invoke(
+	ctx context.Context,
+	envelope Envelope,
+	metadata Metadata,
+	handlers ...func(context.Context) error,
 ) error {
-	var event Event
+	if c.synchronous {
+		calls := make([]func() error, 0, len(handlers))
+
+		for _, handler := range handlers {
+			next := c.chain(func(ctx context.Context, _ Envelope) error {
+				return handler(ctx)
+			})
 
-	if handler != nil {
-		if err := json.Unmarshal(eventPayload, &event); err != nil {
-			return fmt.Errorf("unmarshal event payload: %w", err)
+			calls = append(calls, func() error {
+				return next(ctx, envelope)
+			})
 		}
 
-		go handler(event, eventMetadata)
+		return c.callAll(calls)
+	}
+
+	// Handlers outlive processing of the message, so they must not be canceled along with it.
+	ctx = context.WithoutCancel(ctx)
+
+	dispatched := make([]func(), 0, len(handlers))
+
+	for _, handler := range handlers {
+		next := c.chain(func(ctx context.Context, _ Envelope) error {
+			return handler(ctx)
+		})
+
+		dispatched = append(dispatched, func() {
+			err := next(ctx, envelope)
+			if err == nil {
+				return
+			}
+
+			if onHandlerError := c.getOnHandlerError(); onHandlerError != nil {
+				onHandlerError(err, metadata)
+			}
+		})
 	}
 
-	return nil
+	return c.dispatchAll(dispatched)
 }
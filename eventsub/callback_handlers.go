@@ -0,0 +1,64 @@
+package eventsub
+
+import (
+	"slices"
+	"sync"
+)
+
+// handlers is a concurrency-safe list of handlers of the event.
+//
+// List is copied on write, so handlers can be added or removed while the event is dispatched to the previous list.
+type handlers[Event, Metadata any] struct {
+	mu      sync.RWMutex
+	lastId  uint64
+	entries []handlerEntry[Event, Metadata]
+}
+
+type handlerEntry[Event, Metadata any] struct {
+	id      uint64
+	handler ContextHandler[Event, Metadata]
+}
+
+// add adds handler to the list and returns function that removes it. Nil handler is not added.
+func (h *handlers[Event, Metadata]) add(handler ContextHandler[Event, Metadata]) func() {
+	if handler == nil {
+		return func() {}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastId++
+	id := h.lastId
+
+	// Full slice expression forces append to copy the list instead of writing to the shared backing array.
+	h.entries = append(h.entries[:len(h.entries):len(h.entries)], handlerEntry[Event, Metadata]{
+		id:      id,
+		handler: handler,
+	})
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			h.remove(id)
+		})
+	}
+}
+
+func (h *handlers[Event, Metadata]) remove(id uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = slices.DeleteFunc(slices.Clone(h.entries), func(entry handlerEntry[Event, Metadata]) bool {
+		return entry.id == id
+	})
+}
+
+// list returns current list of handlers. Returned list must not be modified.
+func (h *handlers[Event, Metadata]) list() []handlerEntry[Event, Metadata] {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.entries
+}
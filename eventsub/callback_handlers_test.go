@@ -0,0 +1,89 @@
+package eventsub
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHandlersChangedDuringDispatch(t *testing.T) {
+	c := &callback[struct{}]{synchronous: true}
+
+	var (
+		first, second, added int
+		removeFirst          func()
+	)
+
+	removeFirst = On(c, func(ChannelBanEvent, struct{}) {
+		first++
+		removeFirst()
+
+		On(c, func(ChannelBanEvent, struct{}) {
+			added++
+		})
+	})
+
+	On(c, func(ChannelBanEvent, struct{}) {
+		second++
+	})
+
+	rawEvent := RawEvent{Event: []byte(`{}`)}
+
+	for range 2 {
+		if err := c.runEventCallback(context.Background(), EventTypeChannelBan, "1", rawEvent, struct{}{}); err != nil {
+			t.Fatalf("run callback: %v", err)
+		}
+	}
+
+	// Dispatch runs the list of handlers that is current when the event is received.
+	if first != 1 || second != 2 || added != 1 {
+		t.Fatalf("expected handlers to run 1, 2 and 1 times, got %d, %d and %d", first, second, added)
+	}
+}
+
+func TestHandlersConcurrentRegistrationDuringDispatch(t *testing.T) {
+	c := &callback[struct{}]{synchronous: true}
+
+	var calls atomic.Int64
+
+	On(c, func(ChannelBanEvent, struct{}) {
+		calls.Add(1)
+	})
+
+	rawEvent := RawEvent{Event: []byte(`{}`)}
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				if err := c.runEventCallback(context.Background(), EventTypeChannelBan, "1", rawEvent, struct{}{}); err != nil {
+					t.Errorf("run callback: %v", err)
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				remove := On(c, func(ChannelBanEvent, struct{}) {})
+				removeAny := c.OnAny(func(Event, struct{}) {})
+
+				remove()
+				removeAny()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls.Load() != 400 {
+		t.Fatalf("expected permanent handler to run 400 times, got %d", calls.Load())
+	}
+}
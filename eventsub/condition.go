@@ -5,8 +5,14 @@ type Condition interface {
 	condition()
 }
 
+// AnyCondition is a condition of any subscription type as a set of raw key-value pairs. It's useful when subscriptions of
+// different types are handled together (e.g. when listing subscriptions).
+type AnyCondition map[string]string
+
+func (AnyCondition) condition() {}
+
 type AutomodMessageHoldCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster (channel).
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -14,7 +20,7 @@ type AutomodMessageHoldCondition struct {
 }
 
 type AutomodMessageUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster (channel), maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -22,7 +28,7 @@ type AutomodMessageUpdateCondition struct {
 }
 
 type AutomodSettingsUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster (channel), maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -30,7 +36,7 @@ type AutomodSettingsUpdateCondition struct {
 }
 
 type AutomodTermsUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster (channel), maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -38,25 +44,25 @@ type AutomodTermsUpdateCondition struct {
 }
 
 type ChannelAdBreakBeginCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterId is an id of the broadcaster that you want to get channel ad break begin notifications for, maximum 1.
 	BroadcasterId string `json:"broadcaster_user_id"`
 }
 
 type ChannelBanCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get ban notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelBitsUseCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel broadcaster, maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelChatClearCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat clear events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -64,7 +70,7 @@ type ChannelChatClearCondition struct {
 }
 
 type ChannelChatClearUserMessagesCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat clear user messages events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -72,7 +78,7 @@ type ChannelChatClearUserMessagesCondition struct {
 }
 
 type ChannelChatMessageCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat message events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -80,7 +86,7 @@ type ChannelChatMessageCondition struct {
 }
 
 type ChannelChatMessageDeleteCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat message delete events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -88,7 +94,7 @@ type ChannelChatMessageDeleteCondition struct {
 }
 
 type ChannelChatNotificationCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat notification events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -96,7 +102,7 @@ type ChannelChatNotificationCondition struct {
 }
 
 type ChannelChatSettingsUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat settings update events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -104,7 +110,7 @@ type ChannelChatSettingsUpdateCondition struct {
 }
 
 type ChannelChatUserMessageHoldCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat message events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -112,7 +118,7 @@ type ChannelChatUserMessageHoldCondition struct {
 }
 
 type ChannelChatUserMessageUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive chat message events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// UserId is a user ID to read chat as.
@@ -120,43 +126,43 @@ type ChannelChatUserMessageUpdateCondition struct {
 }
 
 type ChannelSubscribeCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get subscribe notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelSubscriptionEndCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get subscription end notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelSubscriptionGiftCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get subscription gift notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelSubscriptionMessageCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get resubscription chat message notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelCheerCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get cheer notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get updates for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelFollowCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get follow notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is an ID of the moderator of the channel you want to get follow notifications for.
@@ -165,13 +171,13 @@ type ChannelFollowCondition struct {
 }
 
 type ChannelUnbanCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get unban notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelUnbanRequestCreateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is an ID of the broadcaster you want to get chat unban request notifications for, maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is an ID of the user that has permission to moderate the broadcaster’s channel and has granted
@@ -180,7 +186,7 @@ type ChannelUnbanRequestCreateCondition struct {
 }
 
 type ChannelUnbanRequestResolveCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is an ID of the broadcaster you want to get unban request resolution notifications for, maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is an ID of the user that has permission to moderate the broadcaster’s channel and has granted
@@ -189,19 +195,19 @@ type ChannelUnbanRequestResolveCondition struct {
 }
 
 type ChannelRaidCondition struct {
-	Condition
+	Condition `json:"-"`
 	// FromBroadcasterUserId is a broadcaster user ID that created the channel raid you want to get notifications for.
 	// Use this parameter if you want to know when a specific broadcaster raids another broadcaster. The channel raid
 	// condition must include either from_broadcaster_user_id or to_broadcaster_user_id.
-	FromBroadcasterUserId string `json:"from_broadcaster_user_id"`
+	FromBroadcasterUserId string `json:"from_broadcaster_user_id,omitempty"`
 	// ToBroadcasterUserId is a broadcaster user ID that received the channel raid you want to get notifications for.
 	// Use this parameter if you want to know when a specific broadcaster is raided by another broadcaster. The channel
 	// raid condition must include either from_broadcaster_user_id or to_broadcaster_user_id.
-	ToBroadcasterUserId string `json:"to_broadcaster_user_id"`
+	ToBroadcasterUserId string `json:"to_broadcaster_user_id,omitempty"`
 }
 
 type ChannelModerateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -209,7 +215,7 @@ type ChannelModerateCondition struct {
 }
 
 type ChannelModerateV2Condition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -217,19 +223,19 @@ type ChannelModerateV2Condition struct {
 }
 
 type ChannelModeratorAddCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get moderator addition notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelModeratorRemoveCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to get moderator removal notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelGuestStarSessionBeginCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel hosting the guest star session.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator or broadcaster of the specified channel.
@@ -237,7 +243,7 @@ type ChannelGuestStarSessionBeginCondition struct {
 }
 
 type ChannelGuestStarSessionEndCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel hosting the guest star session.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator or broadcaster of the specified channel.
@@ -245,7 +251,7 @@ type ChannelGuestStarSessionEndCondition struct {
 }
 
 type ChannelGuestStarGuestUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel hosting the guest star session.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator or broadcaster of the specified channel.
@@ -253,7 +259,7 @@ type ChannelGuestStarGuestUpdateCondition struct {
 }
 
 type ChannelGuestStarSettingsUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel hosting the guest star session.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator or broadcaster of the specified channel.
@@ -261,25 +267,25 @@ type ChannelGuestStarSettingsUpdateCondition struct {
 }
 
 type ChannelPointsAutomaticRewardRedemptionAddCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to receive channel points reward add notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPointsAutomaticRewardRedemptionAddV2Condition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to receive channel points reward add notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPointsCustomRewardAddCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to receive channel points custom reward add notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPointsCustomRewardUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to receive channel points custom reward update notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// RewardId is a reward id to only receive notifications for a specific reward, optional.
@@ -287,7 +293,7 @@ type ChannelPointsCustomRewardUpdateCondition struct {
 }
 
 type ChannelPointsCustomRewardRemoveCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to receive channel points custom reward remove notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// RewardId is a reward id to only receive notifications for a specific reward, optional.
@@ -295,7 +301,7 @@ type ChannelPointsCustomRewardRemoveCondition struct {
 }
 
 type ChannelPointsCustomRewardRedemptionAddCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to receive channel points custom reward redemption add notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// RewardId is a reward id to only receive notifications for a specific reward, optional.
@@ -303,7 +309,7 @@ type ChannelPointsCustomRewardRedemptionAddCondition struct {
 }
 
 type ChannelPointsCustomRewardRedemptionUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID for the channel you want to receive channel points custom reward redemption update notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// RewardId is a reward id to only receive notifications for a specific reward, optional.
@@ -311,67 +317,67 @@ type ChannelPointsCustomRewardRedemptionUpdateCondition struct {
 }
 
 type ChannelPollBeginCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel for which “poll begin” notifications will be received.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPollProgressCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel for which “poll progress” notifications will be received.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPollEndCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel for which “poll end” notifications will be received.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPredictionBeginCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel for which “prediction begin” notifications will be received.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPredictionProgressCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel for which “prediction progress” notifications will be received.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPredictionLockCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel for which “prediction lock” notifications will be received.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelPredictionEndCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID of the channel for which “prediction end” notifications will be received.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelSharedChatSessionBeginCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive shared chat session begin events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelSharedChatSessionUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive shared chat session update events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelSharedChatSessionEndCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the channel to receive shared chat session end events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelSuspiciousUserMessageCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is an ID of the channel to receive chat message events for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is an ID of a user that has permission to moderate the broadcaster’s channel and has granted your
@@ -380,7 +386,7 @@ type ChannelSuspiciousUserMessageCondition struct {
 }
 
 type ChannelSuspiciousUserUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster you want to get chat unban request notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is an ID of a user that has permission to moderate the broadcaster’s channel and has granted your
@@ -389,19 +395,19 @@ type ChannelSuspiciousUserUpdateCondition struct {
 }
 
 type ChannelVIPAddCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster (channel), maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelVIPRemoveCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster (channel), maximum 1.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type ChannelWarningAcknowledgeCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -409,7 +415,7 @@ type ChannelWarningAcknowledgeCondition struct {
 }
 
 type ChannelWarningSendCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a user ID of the broadcaster.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 	// ModeratorUserId is a user ID of the moderator.
@@ -417,85 +423,85 @@ type ChannelWarningSendCondition struct {
 }
 
 type ConduitShardDisabledCondition struct {
-	Condition
+	Condition `json:"-"`
 	// ClientId is your application’s client id. The provided client_id must match the client ID in the application access token.
-	ClientId string `json:"broadcaster_user_id"`
-	// ModeratorUserId is a conduit ID to receive events for. If omitted, events for all of this client’s conduits are sent.
-	ConduitId string `json:"moderator_user_id"`
+	ClientId string `json:"client_id"`
+	// ConduitId is a conduit ID to receive events for. If omitted, events for all of this client’s conduits are sent.
+	ConduitId string `json:"conduit_id,omitempty"`
 }
 
 type DropEntitlementGrantCondition struct {
-	Condition
+	Condition `json:"-"`
 	// OrganizationId is an organization ID of the organization that owns the game on the developer portal.
 	OrganizationId string `json:"organization_id"`
 	// CategoryId is a category (or game) ID of the game for which entitlement notifications will be received.
-	CategoryId string `json:"category_id"`
+	CategoryId string `json:"category_id,omitempty"`
 	// CampaignId is a campaign ID for a specific campaign for which entitlement notifications will be received.
-	CampaignId string `json:"campaign_id"`
+	CampaignId string `json:"campaign_id,omitempty"`
 }
 
 type ExtensionBitsTransactionCreateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// ExtensionClientId is a client ID of the extension.
 	ExtensionClientId string `json:"extension_client_id"`
 }
 
 type GoalsCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is an ID of the broadcaster to get notified about. The ID must match the user_id in the OAuth access token.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type HypeTrainBeginCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is an ID of the broadcaster that you want to get hype train begin notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type HypeTrainProgressCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is an ID of the broadcaster that you want to get hype train progress notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type HypeTrainEndCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is an ID of the broadcaster that you want to get hype train end notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type StreamOnlineCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID you want to get stream online notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type StreamOfflineCondition struct {
-	Condition
+	Condition `json:"-"`
 	// BroadcasterUserId is a broadcaster user ID you want to get stream offline notifications for.
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
 type UserAuthorizationGrantCondition struct {
-	Condition
+	Condition `json:"-"`
 	// ClientId is your application’s client id. The provided client_id must match the client id in the application access token.
 	ClientId string `json:"client_id"`
 }
 
 type UserAuthorizationRevokeCondition struct {
-	Condition
+	Condition `json:"-"`
 	// ClientId is your application’s client id. The provided client_id must match the client id in the application access token.
 	ClientId string `json:"client_id"`
 }
 
 type UserUpdateCondition struct {
-	Condition
+	Condition `json:"-"`
 	// UserId is a user ID for the user you want update notifications for.
 	UserId string `json:"user_id"`
 }
 
 type WhisperReceivedCondition struct {
-	Condition
+	Condition `json:"-"`
 	// UserId is a user_id of the person receiving whispers
 	UserId string `json:"user_id"`
 }
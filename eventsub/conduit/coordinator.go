@@ -0,0 +1,430 @@
+package conduit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twirapp/twitchy/eventsub"
+	"github.com/twirapp/twitchy/helix"
+)
+
+var (
+	// ErrConduitNotFound indicates that conduit with the Coordinator's conduit id doesn't exist.
+	ErrConduitNotFound = errors.New("conduit not found")
+	// ErrNoFreeShard indicates that all shards of the conduit are already claimed by other websocket clients.
+	ErrNoFreeShard = errors.New("no free shard to claim")
+	// ErrLeaseLost indicates that shard lease has been acquired by another owner (e.g. after lease expiration).
+	ErrLeaseLost = errors.New("shard lease lost")
+	// ErrInvalidLeaseTTL indicates that TTL of shard leases is too short to extend leases before they expire.
+	ErrInvalidLeaseTTL = errors.New("lease TTL is less than 3 milliseconds")
+)
+
+// minLeaseTTL is a minimum TTL of shard leases, as leases are extended every third of the TTL and lockers store it with
+// millisecond precision.
+const minLeaseTTL = 3 * time.Millisecond
+
+// Coordinator owns a set of websocket clients and keeps each of them assigned to its own shard of the conduit.
+//
+// Coordinator connects clients, claims a free shard for the session id of each welcome message (including welcome
+// messages sent after reconnect), releases shard when client is disconnected and reconnects client when its shard is
+// disabled. Shard ownership is coordinated across instances of your application through the Locker.
+//
+// Coordinator sets OnWelcome and OnDisconnect callbacks of its clients, so they must not be set by the user. Use
+// WithOnWelcome and WithOnDisconnect options instead, the Coordinator calls them after its own callbacks. Coordinator
+// also adds OnConduitShardDisabled handler to its clients and removes it when Run returns.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/handling-conduit-events.
+type Coordinator struct {
+	helix     *helix.Helix
+	conduitId string
+	clients   []*eventsub.Websocket
+
+	locker         Locker
+	owner          string
+	leaseTTL       time.Duration
+	reconnectDelay time.Duration
+
+	shards map[*eventsub.Websocket]string
+	// claiming is a set of shards which leases are being acquired, so clients don't claim the same shard concurrently.
+	claiming map[string]struct{}
+	mu       sync.Mutex
+
+	onClaim      func(shardId, sessionId string)
+	onWelcome    func(*eventsub.Websocket, eventsub.WebsocketWelcomeMessage)
+	onDisconnect func(*eventsub.Websocket)
+	onError      func(error)
+}
+
+// NewCoordinator returns new Coordinator of the conduit shards. ErrInvalidLeaseTTL is returned if lease TTL set by
+// WithLeaseTTL is too short.
+func NewCoordinator(
+	helix *helix.Helix,
+	conduitId string,
+	clients []*eventsub.Websocket,
+	options ...Option,
+) (*Coordinator, error) {
+	coordinator := &Coordinator{
+		helix:          helix,
+		conduitId:      conduitId,
+		clients:        clients,
+		locker:         NewInMemoryLocker(),
+		owner:          randomOwner(),
+		leaseTTL:       30 * time.Second,
+		reconnectDelay: 1 * time.Second,
+		shards:         make(map[*eventsub.Websocket]string),
+		claiming:       make(map[string]struct{}),
+	}
+
+	for _, option := range options {
+		option(coordinator)
+	}
+
+	if coordinator.leaseTTL < minLeaseTTL {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLeaseTTL, coordinator.leaseTTL)
+	}
+
+	return coordinator, nil
+}
+
+// Run connects all websocket clients and blocks on keeping them connected and assigned to conduit shards until the
+// context is canceled. All clients are disconnected and their shards are released on return.
+func (c *Coordinator) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	for _, client := range c.clients {
+		removeCallbacks := c.setCallbacks(ctx, client)
+		defer removeCallbacks()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.keepConnected(ctx, client)
+		}()
+	}
+
+	go c.startLeaseWorker(ctx)
+
+	<-ctx.Done()
+
+	for _, client := range c.clients {
+		_ = client.Disconnect()
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// Shards returns shard ids that are currently claimed by the Coordinator's websocket clients.
+func (c *Coordinator) Shards() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	shards := make([]string, 0, len(c.shards))
+	for _, shardId := range c.shards {
+		shards = append(shards, shardId)
+	}
+
+	return shards
+}
+
+// HandleConduitShardDisabled reconnects websocket client that owns the disabled shard, so it will be claimed again.
+// It's called automatically for events received by the Coordinator's clients, but you can also call it with events
+// received by other transports (e.g. Webhook).
+func (c *Coordinator) HandleConduitShardDisabled(event eventsub.ConduitShardDisabledEvent) {
+	if event.ConduitId != c.conduitId {
+		return
+	}
+
+	c.mu.Lock()
+
+	var owner *eventsub.Websocket
+
+	for client, shardId := range c.shards {
+		if shardId == event.ShardId {
+			owner = client
+			break
+		}
+	}
+
+	c.mu.Unlock()
+
+	if owner == nil {
+		return
+	}
+
+	if err := owner.Disconnect(); err != nil {
+		c.reportError(fmt.Errorf("disconnect client of disabled shard %s: %w", event.ShardId, err))
+	}
+}
+
+// setCallbacks sets callbacks of the Coordinator to the client, chained with the user's callbacks set by options, and
+// returns function that removes OnConduitShardDisabled handler.
+func (c *Coordinator) setCallbacks(ctx context.Context, client *eventsub.Websocket) func() {
+	client.OnWelcome(func(message eventsub.WebsocketWelcomeMessage) {
+		if err := c.claim(ctx, client, message.Payload.Session.Id); err != nil {
+			c.reportError(fmt.Errorf("claim shard: %w", err))
+		}
+
+		if c.onWelcome != nil {
+			c.onWelcome(client, message)
+		}
+	})
+
+	client.OnDisconnect(func() {
+		c.release(context.WithoutCancel(ctx), client)
+
+		if c.onDisconnect != nil {
+			c.onDisconnect(client)
+		}
+	})
+
+	return client.OnConduitShardDisabled(func(
+		event eventsub.ConduitShardDisabledEvent,
+		_ eventsub.WebsocketNotificationMetadata,
+	) {
+		c.HandleConduitShardDisabled(event)
+	})
+}
+
+// keepConnected blocks on connecting websocket client again each time it's disconnected until context is canceled.
+func (c *Coordinator) keepConnected(ctx context.Context, client *eventsub.Websocket) {
+	for {
+		if err := client.Connect(ctx); err != nil {
+			c.reportError(fmt.Errorf("connect: %w", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.reconnectDelay):
+		}
+	}
+}
+
+// claim assigns websocket session of the client to the shard that is already owned by the client or to the first free
+// shard of the conduit.
+//
+// Calls to the locker and Helix API are made without holding the lock, so slow calls don't block other clients.
+func (c *Coordinator) claim(ctx context.Context, client *eventsub.Websocket, sessionId string) error {
+	c.mu.Lock()
+	shardId, ok := c.shards[client]
+	c.mu.Unlock()
+
+	if ok {
+		acquired, err := c.locker.Acquire(ctx, c.leaseKey(shardId), c.owner, c.leaseTTL)
+		if err != nil {
+			return fmt.Errorf("acquire owned shard: %w", err)
+		}
+
+		if !acquired {
+			c.forget(client, shardId)
+			ok = false
+		}
+	}
+
+	if !ok {
+		var err error
+
+		if shardId, err = c.acquireFreeShard(ctx); err != nil {
+			return err
+		}
+
+		defer c.finishClaiming(shardId)
+	}
+
+	updated, err := c.helix.UpdateConduitShards(ctx, c.conduitId, []helix.ConduitShardUpdate{
+		helix.WebsocketConduitShardUpdate(shardId, sessionId),
+	})
+	if err == nil && len(updated.Errors) > 0 {
+		err = updated.Errors[0]
+	}
+	if err != nil {
+		c.forget(client, shardId)
+		_ = c.locker.Release(ctx, c.leaseKey(shardId), c.owner)
+
+		return fmt.Errorf("update conduit shard: %w", err)
+	}
+
+	c.mu.Lock()
+	c.shards[client] = shardId
+	c.mu.Unlock()
+
+	if c.onClaim != nil {
+		go c.onClaim(shardId, sessionId)
+	}
+
+	return nil
+}
+
+// acquireFreeShard acquires lease on the first shard of the conduit that is not owned by anyone. Acquired shard is
+// marked as being claimed, so finishClaiming must be called after it's assigned to the client.
+func (c *Coordinator) acquireFreeShard(ctx context.Context) (string, error) {
+	conduits, err := c.helix.GetConduits(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get conduits: %w", err)
+	}
+
+	shardCount := -1
+
+	for _, conduit := range conduits {
+		if conduit.Id == c.conduitId {
+			shardCount = conduit.ShardCount
+			break
+		}
+	}
+
+	if shardCount < 0 {
+		return "", ErrConduitNotFound
+	}
+
+	for i := range shardCount {
+		shardId := strconv.Itoa(i)
+
+		if !c.startClaiming(shardId) {
+			continue
+		}
+
+		acquired, err := c.locker.Acquire(ctx, c.leaseKey(shardId), c.owner, c.leaseTTL)
+		if err != nil {
+			c.finishClaiming(shardId)
+			return "", fmt.Errorf("acquire shard %s: %w", shardId, err)
+		}
+
+		if acquired {
+			return shardId, nil
+		}
+
+		c.finishClaiming(shardId)
+	}
+
+	return "", ErrNoFreeShard
+}
+
+// startClaiming marks shard as being claimed and returns true if it's neither owned by nor being claimed by other
+// clients of the Coordinator.
+func (c *Coordinator) startClaiming(shardId string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.claiming[shardId]; ok {
+		return false
+	}
+
+	for _, ownedShardId := range c.shards {
+		if ownedShardId == shardId {
+			return false
+		}
+	}
+
+	c.claiming[shardId] = struct{}{}
+
+	return true
+}
+
+func (c *Coordinator) finishClaiming(shardId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.claiming, shardId)
+}
+
+// forget removes shard from the client if it's still owned by the client.
+func (c *Coordinator) forget(client *eventsub.Websocket, shardId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shards[client] == shardId {
+		delete(c.shards, client)
+	}
+}
+
+// release releases shard owned by the client if any.
+func (c *Coordinator) release(ctx context.Context, client *eventsub.Websocket) {
+	c.mu.Lock()
+
+	shardId, ok := c.shards[client]
+	if ok {
+		delete(c.shards, client)
+	}
+
+	c.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if err := c.locker.Release(ctx, c.leaseKey(shardId), c.owner); err != nil {
+		c.reportError(fmt.Errorf("release shard %s: %w", shardId, err))
+	}
+}
+
+// startLeaseWorker starts and blocks on extending leases of the owned shards until context is canceled. Clients which
+// shards leases were lost are reconnected to claim another shard.
+func (c *Coordinator) startLeaseWorker(ctx context.Context) {
+	ticker := time.NewTicker(c.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, client := range c.extendLeases(ctx) {
+				if err := client.Disconnect(); err != nil {
+					c.reportError(fmt.Errorf("disconnect client of lost shard: %w", err))
+				}
+			}
+		}
+	}
+}
+
+// extendLeases extends leases of the owned shards and returns clients which shards leases were lost.
+func (c *Coordinator) extendLeases(ctx context.Context) []*eventsub.Websocket {
+	c.mu.Lock()
+	shards := maps.Clone(c.shards)
+	c.mu.Unlock()
+
+	var lost []*eventsub.Websocket
+
+	for client, shardId := range shards {
+		acquired, err := c.locker.Acquire(ctx, c.leaseKey(shardId), c.owner, c.leaseTTL)
+		if err != nil {
+			c.reportError(fmt.Errorf("extend shard %s lease: %w", shardId, err))
+			continue
+		}
+
+		if !acquired {
+			c.forget(client, shardId)
+			lost = append(lost, client)
+
+			c.reportError(fmt.Errorf("shard %s: %w", shardId, ErrLeaseLost))
+		}
+	}
+
+	return lost
+}
+
+func (c *Coordinator) leaseKey(shardId string) string {
+	return c.conduitId + ":" + shardId
+}
+
+func (c *Coordinator) reportError(err error) {
+	if c.onError != nil {
+		go c.onError(err)
+	}
+}
+
+func randomOwner() string {
+	owner := make([]byte, 16)
+	_, _ = rand.Read(owner)
+
+	return hex.EncodeToString(owner)
+}
@@ -0,0 +1,16 @@
+package conduit
+
+import (
+	"context"
+	"time"
+)
+
+// Locker is a lease-based lock that is used by Coordinator to make sure that each conduit shard is claimed by only one
+// websocket client across all instances of your application.
+type Locker interface {
+	// Acquire acquires lease on the key for the owner with provided TTL and returns if lease was acquired. Acquiring a
+	// lease that is already held by the same owner must extend its TTL.
+	Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Release releases lease on the key if it's held by the owner.
+	Release(ctx context.Context, key, owner string) error
+}
@@ -0,0 +1,57 @@
+package conduit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemoryLocker is a standard in-memory concurrent safe implementation of Locker, which is suitable for cases when
+// all websocket clients of the conduit are running in a single process.
+type InMemoryLocker struct {
+	leases map[string]lease
+	mu     sync.Mutex
+}
+
+type lease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+var _ Locker = (*InMemoryLocker)(nil)
+
+func NewInMemoryLocker() *InMemoryLocker {
+	return &InMemoryLocker{
+		leases: make(map[string]lease),
+	}
+}
+
+func (iml *InMemoryLocker) Acquire(_ context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	iml.mu.Lock()
+	defer iml.mu.Unlock()
+
+	now := time.Now()
+
+	current, ok := iml.leases[key]
+	if ok && current.owner != owner && now.Before(current.expiresAt) {
+		return false, nil
+	}
+
+	iml.leases[key] = lease{
+		owner:     owner,
+		expiresAt: now.Add(ttl),
+	}
+
+	return true, nil
+}
+
+func (iml *InMemoryLocker) Release(_ context.Context, key, owner string) error {
+	iml.mu.Lock()
+	defer iml.mu.Unlock()
+
+	if current, ok := iml.leases[key]; ok && current.owner == owner {
+		delete(iml.leases, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package conduit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisKeyBuilder builds and returns key for Redis to store lease with provided lease key.
+type RedisKeyBuilder func(key string) string
+
+var (
+	// acquireScript sets the lease if it's not held by anyone or extends it if it's held by the same owner.
+	acquireScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current == false or current == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return 1
+end
+return 0
+`)
+
+	// releaseScript deletes the lease only if it's held by the owner.
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// RedisLocker is a standard Redis implementation of Locker with official Redis client, which is suitable for cases when
+// websocket clients of the conduit are spread across multiple instances of your application.
+type RedisLocker struct {
+	client *redis.Client
+	key    RedisKeyBuilder
+}
+
+var _ Locker = (*RedisLocker)(nil)
+
+func NewRedisLocker(client *redis.Client, key RedisKeyBuilder) (RedisLocker, error) {
+	if key == nil {
+		return RedisLocker{}, errors.New("key builder is not provided")
+	}
+
+	return RedisLocker{
+		client: client,
+		key:    key,
+	}, nil
+}
+
+func (rl RedisLocker) Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	acquired, err := acquireScript.Run(ctx, rl.client, []string{rl.key(key)}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("run acquire script: %w", err)
+	}
+
+	return acquired == 1, nil
+}
+
+func (rl RedisLocker) Release(ctx context.Context, key, owner string) error {
+	if err := releaseScript.Run(ctx, rl.client, []string{rl.key(key)}, owner).Err(); err != nil {
+		return fmt.Errorf("run release script: %w", err)
+	}
+
+	return nil
+}
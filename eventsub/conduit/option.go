@@ -0,0 +1,78 @@
+package conduit
+
+import (
+	"time"
+
+	"github.com/twirapp/twitchy/eventsub"
+)
+
+// Option is an optional setting for Coordinator.
+type Option func(*Coordinator)
+
+// WithLocker sets locker that will be used to coordinate shard ownership across instances of your application.
+//
+// Default value is InMemoryLocker, which is suitable only when all websocket clients are running in a single process.
+func WithLocker(locker Locker) Option {
+	return func(c *Coordinator) {
+		c.locker = locker
+	}
+}
+
+// WithOwner sets identifier of the Coordinator that is stored in shard leases. It must be unique across instances of
+// your application.
+//
+// Default value is a random identifier.
+func WithOwner(owner string) Option {
+	return func(c *Coordinator) {
+		c.owner = owner
+	}
+}
+
+// WithLeaseTTL sets TTL of shard leases. Leases are extended every third of the TTL while websocket client that owns
+// the shard is connected. TTL must be at least 3 milliseconds, otherwise NewCoordinator returns ErrInvalidLeaseTTL.
+//
+// Default value is 30 seconds.
+func WithLeaseTTL(ttl time.Duration) Option {
+	return func(c *Coordinator) {
+		c.leaseTTL = ttl
+	}
+}
+
+// WithReconnectDelay sets delay between attempts to connect websocket client after it has been disconnected.
+//
+// Default value is 1 second.
+func WithReconnectDelay(delay time.Duration) Option {
+	return func(c *Coordinator) {
+		c.reconnectDelay = delay
+	}
+}
+
+// WithOnClaim sets callback that invokes when websocket session is assigned to the conduit shard.
+func WithOnClaim(onClaim func(shardId, sessionId string)) Option {
+	return func(c *Coordinator) {
+		c.onClaim = onClaim
+	}
+}
+
+// WithOnWelcome sets callback that invokes when websocket client of the Coordinator receives welcome message. Use it
+// instead of the client's OnWelcome, as the Coordinator sets that callback.
+func WithOnWelcome(onWelcome func(client *eventsub.Websocket, message eventsub.WebsocketWelcomeMessage)) Option {
+	return func(c *Coordinator) {
+		c.onWelcome = onWelcome
+	}
+}
+
+// WithOnDisconnect sets callback that invokes when websocket client of the Coordinator is disconnected. Use it instead
+// of the client's OnDisconnect, as the Coordinator sets that callback.
+func WithOnDisconnect(onDisconnect func(client *eventsub.Websocket)) Option {
+	return func(c *Coordinator) {
+		c.onDisconnect = onDisconnect
+	}
+}
+
+// WithOnError sets callback that invokes when Coordinator fails to connect websocket client or to claim a shard.
+func WithOnError(onError func(error)) Option {
+	return func(c *Coordinator) {
+		c.onError = onError
+	}
+}
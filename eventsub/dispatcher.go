@@ -0,0 +1,290 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrShuttingDown indicates that handler is not run as client is shutting down.
+var ErrShuttingDown = errors.New("shutting down")
+
+// ShutdownError is returned from Shutdown if the context is done before all in-flight handlers are finished.
+type ShutdownError struct {
+	// Abandoned is a number of handlers that were still running or queued when shutdown was cut off.
+	Abandoned int
+	Err       error
+}
+
+var _ error = (*ShutdownError)(nil)
+
+func (se *ShutdownError) Error() string {
+	return fmt.Sprintf("%d handlers were abandoned on shutdown: %s", se.Abandoned, se.Err)
+}
+
+func (se *ShutdownError) Unwrap() error {
+	return se.Err
+}
+
+// OverflowPolicy defines what dispatcher does with a handler when its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks processing of new messages until there is a free slot in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest queued handler to free slot for a new one.
+	OverflowDropOldest
+	// OverflowDropNewest drops a new handler.
+	OverflowDropNewest
+	// OverflowCallback hands a new handler over to the overflow callback (see DispatcherWithOnOverflow), so it can
+	// run it synchronously, spawn it or drop it. Handler handed over to the callback is not tracked for shutdown.
+	OverflowCallback
+)
+
+// dispatcher runs user's handlers and tracks them for graceful shutdown.
+//
+// By default, each handler runs in a separate goroutine. If workers are set, handlers are queued to the bounded queue
+// and run by the fixed number of workers, so bursts of events don't spawn unbounded number of goroutines.
+type dispatcher struct {
+	// mu guards closed and queue, so no handler is added after drain started to wait for them. It's never held while
+	// blocking, so drain is not blocked by a full queue.
+	mu     sync.RWMutex
+	closed bool
+
+	// closing is closed when drain starts, so handlers blocked on a full queue are dropped.
+	closing     chan struct{}
+	initClosing sync.Once
+	// sending tracks handlers that are being sent to the queue, so the queue is closed only after they are sent.
+	sending sync.WaitGroup
+
+	workers        int
+	queueSize      int
+	overflowPolicy OverflowPolicy
+	onOverflow     func(handler func())
+
+	queue        chan func()
+	startWorkers sync.Once
+
+	handlers sync.WaitGroup
+	running  atomic.Int64
+}
+
+// dispatch runs handler or queues it to be run by workers. ErrShuttingDown is returned if dispatcher is already closed,
+// so the caller can reject the message instead of losing it.
+func (d *dispatcher) dispatch(handler func()) error {
+	return d.dispatchAll([]func(){handler})
+}
+
+// dispatchAll dispatches handlers of the same message all or nothing. ErrShuttingDown is returned without running any
+// of them if dispatcher is already closed or drain starts before the first of them is queued. Once the first handler
+// is queued, the rest are run even if drain starts, so the message is never handled partially.
+func (d *dispatcher) dispatchAll(handlers []func()) error {
+	d.mu.RLock()
+
+	if d.closed {
+		d.mu.RUnlock()
+		return ErrShuttingDown
+	}
+
+	for range handlers {
+		d.begin()
+	}
+
+	if d.workers <= 0 {
+		d.mu.RUnlock()
+
+		for _, handler := range handlers {
+			go d.run(handler)
+		}
+
+		return nil
+	}
+
+	d.startWorkers.Do(func() {
+		d.queue = make(chan func(), max(d.queueSize, 0))
+
+		for range d.workers {
+			go d.work(d.queue)
+		}
+	})
+
+	d.sending.Add(1)
+	d.mu.RUnlock()
+
+	defer d.sending.Done()
+
+	for i, handler := range handlers {
+		if err := d.enqueue(handler); err != nil {
+			if i == 0 {
+				for range handlers {
+					d.finish()
+				}
+
+				return err
+			}
+
+			// Handlers of the message are already queued, so the rest can't be rejected and run without the queue.
+			go d.run(handler)
+		}
+	}
+
+	return nil
+}
+
+// callAll runs handlers of the same message synchronously one by one and returns their joined errors. ErrShuttingDown
+// is returned without running any of them if dispatcher is already closed.
+func (d *dispatcher) callAll(handlers []func() error) error {
+	d.mu.RLock()
+
+	if d.closed {
+		d.mu.RUnlock()
+		return ErrShuttingDown
+	}
+
+	d.begin()
+	d.mu.RUnlock()
+
+	defer d.finish()
+
+	var errs []error
+
+	for _, handler := range handlers {
+		if err := handler(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// enqueue puts handler to the queue according to the overflow policy. ErrShuttingDown is returned if handler is
+// blocked on a full queue when drain starts, handler is not marked as finished then.
+func (d *dispatcher) enqueue(handler func()) error {
+	// Queue without buffer never has a queued handler to drop, so OverflowDropOldest blocks like OverflowBlock instead
+	// of spinning until a worker is free.
+	if d.overflowPolicy == OverflowBlock || (d.overflowPolicy == OverflowDropOldest && cap(d.queue) == 0) {
+		select {
+		case d.queue <- handler:
+			return nil
+		case <-d.closingSignal():
+			return ErrShuttingDown
+		}
+	}
+
+	select {
+	case d.queue <- handler:
+		return nil
+	default:
+	}
+
+	switch d.overflowPolicy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case <-d.queue:
+				d.finish()
+			default:
+			}
+
+			select {
+			case d.queue <- handler:
+				return nil
+			default:
+			}
+		}
+	case OverflowCallback:
+		d.finish()
+
+		if d.onOverflow != nil {
+			d.onOverflow(handler)
+		}
+	default:
+		d.finish()
+	}
+
+	return nil
+}
+
+func (d *dispatcher) closingSignal() chan struct{} {
+	d.initClosing.Do(func() {
+		d.closing = make(chan struct{})
+	})
+
+	return d.closing
+}
+
+// work runs queued handlers until the queue is closed.
+func (d *dispatcher) work(queue <-chan func()) {
+	for handler := range queue {
+		d.run(handler)
+	}
+}
+
+func (d *dispatcher) run(handler func()) {
+	defer d.finish()
+
+	handler()
+}
+
+// begin tracks a new handler.
+func (d *dispatcher) begin() {
+	d.handlers.Add(1)
+	d.running.Add(1)
+}
+
+// finish marks tracked handler as finished (or dropped).
+func (d *dispatcher) finish() {
+	d.running.Add(-1)
+	d.handlers.Done()
+}
+
+// isClosed returns true if dispatcher doesn't accept new handlers.
+func (d *dispatcher) isClosed() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.closed
+}
+
+// drain closes dispatcher for new handlers and waits for in-flight and queued ones to finish until the context is
+// done.
+func (d *dispatcher) drain(ctx context.Context) error {
+	closing := d.closingSignal()
+
+	d.mu.Lock()
+
+	closed := d.closed
+	d.closed = true
+	queue := d.queue
+
+	d.mu.Unlock()
+
+	if !closed {
+		close(closing)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		// Workers run the rest of queued handlers and exit once blocked handlers are either sent or dropped.
+		if !closed && queue != nil {
+			d.sending.Wait()
+			close(queue)
+		}
+
+		d.handlers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return &ShutdownError{
+			Abandoned: int(d.running.Load()),
+			Err:       ctx.Err(),
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package eventsub
+
+// DispatcherOption is an optional setting for dispatcher that runs event handlers of Websocket or Webhook.
+type DispatcherOption func(*dispatcher)
+
+// DispatcherWithWorkers sets number of workers that run event handlers. Zero means that each handler runs in a
+// separate goroutine and there is no limit for number of concurrently running handlers.
+//
+// Default value is 0.
+func DispatcherWithWorkers(workers int) DispatcherOption {
+	return func(d *dispatcher) {
+		d.workers = workers
+	}
+}
+
+// DispatcherWithQueueSize sets size of the queue of handlers waiting for a free worker. It's used only if workers are
+// set with DispatcherWithWorkers. Zero means that handlers are handed over to free workers directly, OverflowDropOldest
+// policy blocks like OverflowBlock then, as there is no queued handler to drop.
+//
+// Default value is 1024.
+func DispatcherWithQueueSize(size int) DispatcherOption {
+	return func(d *dispatcher) {
+		d.queueSize = size
+	}
+}
+
+// DispatcherWithOverflowPolicy sets what dispatcher does with a handler when its queue is full.
+//
+// Default value is OverflowBlock.
+func DispatcherWithOverflowPolicy(policy OverflowPolicy) DispatcherOption {
+	return func(d *dispatcher) {
+		d.overflowPolicy = policy
+	}
+}
+
+// DispatcherWithOnOverflow sets callback that invokes synchronously with a handler that doesn't fit the queue if
+// overflow policy is OverflowCallback.
+func DispatcherWithOnOverflow(onOverflow func(handler func())) DispatcherOption {
+	return func(d *dispatcher) {
+		d.onOverflow = onOverflow
+	}
+}
+
+// applyDispatcherOptions applies options to the dispatcher with default settings.
+func applyDispatcherOptions(d *dispatcher, options []DispatcherOption) {
+	d.queueSize = 1024
+
+	for _, option := range options {
+		option(d)
+	}
+}
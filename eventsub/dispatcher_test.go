@@ -0,0 +1,305 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestDispatcher(options ...DispatcherOption) *dispatcher {
+	d := new(dispatcher)
+	applyDispatcherOptions(d, options)
+
+	return d
+}
+
+func TestDispatcherDrainWaitsForQueuedHandlers(t *testing.T) {
+	d := newTestDispatcher(DispatcherWithWorkers(2), DispatcherWithQueueSize(16))
+
+	var ran atomic.Int64
+
+	for range 10 {
+		err := d.dispatch(func() {
+			time.Sleep(time.Millisecond)
+			ran.Add(1)
+		})
+		if err != nil {
+			t.Fatalf("dispatch: %v", err)
+		}
+	}
+
+	if err := d.drain(context.Background()); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+
+	if ran.Load() != 10 {
+		t.Fatalf("expected 10 handlers to run, got %d", ran.Load())
+	}
+
+	if err := d.dispatch(func() {}); !errors.Is(err, ErrShuttingDown) {
+		t.Fatalf("expected ErrShuttingDown after drain, got %v", err)
+	}
+}
+
+func TestDispatcherDrainRespectsContextWhenQueueIsBlocked(t *testing.T) {
+	d := newTestDispatcher(
+		DispatcherWithWorkers(1),
+		DispatcherWithQueueSize(1),
+		DispatcherWithOverflowPolicy(OverflowBlock),
+	)
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	started := make(chan struct{})
+
+	// The first handler occupies the only worker and the second one occupies the only queue slot.
+	_ = d.dispatch(func() {
+		close(started)
+		<-stuck
+	})
+	<-started
+	_ = d.dispatch(func() {})
+
+	blocked := make(chan error, 1)
+
+	go func() {
+		blocked <- d.dispatch(func() {})
+	}()
+
+	// Let the third handler block on the full queue.
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := d.drain(ctx)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("drain ignored context deadline, took %s", elapsed)
+	}
+
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("expected ShutdownError, got %v", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", shutdownErr.Err)
+	}
+
+	if shutdownErr.Abandoned != 2 {
+		t.Fatalf("expected 2 abandoned handlers, got %d", shutdownErr.Abandoned)
+	}
+
+	select {
+	case err = <-blocked:
+		if !errors.Is(err, ErrShuttingDown) {
+			t.Fatalf("expected blocked dispatch to fail with ErrShuttingDown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked dispatch is not released by drain")
+	}
+}
+
+func TestDispatcherOverflowPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OverflowPolicy
+		expected []int
+		overflow []int
+	}{
+		{
+			name:     "drop newest",
+			policy:   OverflowDropNewest,
+			expected: []int{1},
+		},
+		{
+			name:     "drop oldest",
+			policy:   OverflowDropOldest,
+			expected: []int{3},
+		},
+		{
+			name:     "callback",
+			policy:   OverflowCallback,
+			expected: []int{1},
+			overflow: []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ran        = make(chan int, 8)
+				overflowed []int
+			)
+
+			d := newTestDispatcher(
+				DispatcherWithWorkers(1),
+				DispatcherWithQueueSize(1),
+				DispatcherWithOverflowPolicy(tt.policy),
+				DispatcherWithOnOverflow(func(handler func()) {
+					handler()
+				}),
+			)
+
+			stuck := make(chan struct{})
+			started := make(chan struct{})
+
+			_ = d.dispatch(func() {
+				close(started)
+				<-stuck
+			})
+			<-started
+
+			for i := 1; i <= 3; i++ {
+				err := d.dispatch(func() {
+					ran <- i
+				})
+				if err != nil {
+					t.Fatalf("dispatch %d: %v", i, err)
+				}
+			}
+
+			// Overflow callback runs handlers synchronously in this test.
+			for len(ran) > 0 {
+				overflowed = append(overflowed, <-ran)
+			}
+
+			close(stuck)
+
+			if err := d.drain(context.Background()); err != nil {
+				t.Fatalf("drain: %v", err)
+			}
+
+			close(ran)
+
+			var queued []int
+			for i := range ran {
+				queued = append(queued, i)
+			}
+
+			if !slices.Equal(queued, tt.expected) {
+				t.Fatalf("expected queued handlers %v to run, got %v", tt.expected, queued)
+			}
+
+			if !slices.Equal(overflowed, tt.overflow) {
+				t.Fatalf("expected overflowed handlers %v, got %v", tt.overflow, overflowed)
+			}
+		})
+	}
+}
+
+func TestDispatcherDropOldestWithoutQueueBlocks(t *testing.T) {
+	d := newTestDispatcher(
+		DispatcherWithWorkers(1),
+		DispatcherWithQueueSize(0),
+		DispatcherWithOverflowPolicy(OverflowDropOldest),
+	)
+
+	stuck := make(chan struct{})
+	started := make(chan struct{})
+
+	_ = d.dispatch(func() {
+		close(started)
+		<-stuck
+	})
+	<-started
+
+	var ran atomic.Bool
+
+	dispatched := make(chan error, 1)
+
+	go func() {
+		dispatched <- d.dispatch(func() {
+			ran.Store(true)
+		})
+	}()
+
+	select {
+	case err := <-dispatched:
+		t.Fatalf("expected dispatch to block while the only worker is busy, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(stuck)
+
+	if err := <-dispatched; err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	if err := d.drain(context.Background()); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+
+	if !ran.Load() {
+		t.Fatal("expected blocked handler to run")
+	}
+}
+
+func TestDispatcherDispatchAllIsAllOrNothing(t *testing.T) {
+	d := newTestDispatcher(
+		DispatcherWithWorkers(1),
+		DispatcherWithQueueSize(1),
+		DispatcherWithOverflowPolicy(OverflowBlock),
+	)
+
+	stuck := make(chan struct{})
+	started := make(chan struct{})
+
+	_ = d.dispatch(func() {
+		close(started)
+		<-stuck
+	})
+	<-started
+
+	var ran atomic.Int64
+
+	dispatched := make(chan error, 1)
+
+	// The first handler of the message takes the only queue slot and the second one blocks on the full queue.
+	go func() {
+		dispatched <- d.dispatchAll([]func(){
+			func() { ran.Add(1) },
+			func() { ran.Add(1) },
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	drained := make(chan error, 1)
+
+	go func() {
+		drained <- d.drain(context.Background())
+	}()
+
+	select {
+	case err := <-dispatched:
+		if err != nil {
+			t.Fatalf("expected partially queued message to be accepted, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked dispatch is not released by drain")
+	}
+
+	close(stuck)
+
+	if err := <-drained; err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+
+	if ran.Load() != 2 {
+		t.Fatalf("expected both handlers of the message to run, got %d", ran.Load())
+	}
+
+	err := d.dispatchAll([]func(){
+		func() { t.Error("handler must not run after drain") },
+	})
+	if !errors.Is(err, ErrShuttingDown) {
+		t.Fatalf("expected ErrShuttingDown after drain, got %v", err)
+	}
+}
@@ -152,6 +152,8 @@ type AutomodSettingsUpdateEvent struct {
 	SexBasedTerms int `json:"sex_based_terms"`
 	// The Automod level for profanity.
 	Swearing int `json:"swearing"`
+
+	decodedVersion
 }
 
 type AutomodTermsUpdateEvent struct {
@@ -173,6 +175,8 @@ type AutomodTermsUpdateEvent struct {
 	FromAutomod bool `json:"from_automod"`
 	// The list of terms that had a status change.
 	Terms []string `json:"terms"`
+
+	decodedVersion
 }
 
 type ChannelBitsUseEvent struct {
@@ -196,6 +200,8 @@ type ChannelBitsUseEvent struct {
 	Message *ChannelBitsUseEventMessage `json:"message,omitempty"`
 	// Optional. Data about Power-up.
 	PowerUp *PowerUp `json:"power_up,omitempty"`
+
+	decodedVersion
 }
 
 type ChannelUpdateEvent struct {
@@ -216,6 +222,8 @@ type ChannelUpdateEvent struct {
 	// Content classification label IDs currently applied on the channel.
 	// To retrieve a list of all possible IDs, use the Get Content Classification Labels API endpoint.
 	ContentClassificationLabels []string `json:"content_classification_labels"`
+
+	decodedVersion
 }
 
 type ChannelFollowEvent struct {
@@ -233,6 +241,8 @@ type ChannelFollowEvent struct {
 	BroadcasterUserName string `json:"broadcaster_user_name"`
 	// Timestamp of when the follow occurred.
 	FollowedAt Timestamp `json:"followed_at"`
+
+	decodedVersion
 }
 
 type ChannelAdBreakBeginEvent struct {
@@ -255,6 +265,8 @@ type ChannelAdBreakBeginEvent struct {
 	RequesterUserLogin string `json:"requester_user_login"`
 	// The display name of the user that requested the ad.
 	RequesterUserName string `json:"requester_user_name"`
+
+	decodedVersion
 }
 
 type ChannelChatClearEvent struct {
@@ -264,6 +276,8 @@ type ChannelChatClearEvent struct {
 	BroadcasterUserLogin string `json:"broadcaster_user_login"`
 	// The broadcaster’s user display name.
 	BroadcasterUserName string `json:"broadcaster_user_name"`
+
+	decodedVersion
 }
 
 type ChannelChatClearUserMessagesEvent struct {
@@ -279,6 +293,8 @@ type ChannelChatClearUserMessagesEvent struct {
 	TargetUserLogin string `json:"target_user_login"`
 	// The username of the user that was banned or put in a timeout.
 	TargetUserName string `json:"target_user_name"`
+
+	decodedVersion
 }
 
 type ChannelChatMessageEvent struct {
@@ -335,6 +351,8 @@ type ChannelChatMessageEvent struct {
 	// Optional. Determines if a message delivered during a shared chat session is only sent to the source channel.
 	// Has no effect if the message is not sent during a shared chat session.
 	IsSourceOnly *bool `json:"is_source_only,omitempty"`
+
+	decodedVersion
 }
 
 type ConduitShardDisabledEvent struct {
@@ -346,6 +364,8 @@ type ConduitShardDisabledEvent struct {
 	Status string `json:"status"`
 	// The disabled transport.
 	Transport ConduitShardDisabledEventTransport `json:"transport"`
+
+	decodedVersion
 }
 
 type ChannelBanEvent struct {
@@ -373,6 +393,8 @@ type ChannelBanEvent struct {
 	EndsAt *TimestampUTC `json:"ends_at,omitempty"`
 	// Indicates whether the ban is permanent (true) or a timeout (false). If true, ends_at will be not presented.
 	IsPermanent bool `json:"is_permanent"`
+
+	decodedVersion
 }
 
 type ChannelUnbanEvent struct {
@@ -394,6 +416,8 @@ type ChannelUnbanEvent struct {
 	ModeratorUserLogin string `json:"moderator_user_login"`
 	// The user name of the issuer of the unban.
 	ModeratorUserName string `json:"moderator_user_name"`
+
+	decodedVersion
 }
 
 type ChannelChatNotificationEvent struct {
@@ -512,6 +536,8 @@ type ChannelChatNotificationEvent struct {
 	// This field has the same information as the announcement field but for a notice that happened for a channel in a shared
 	// chat session other than the broadcaster in the subscription condition.
 	SharedChatAnnouncement *ChatNotificationEventAnnouncementEvent `json:"shared_chat_announcement,omitempty"`
+
+	decodedVersion
 }
 
 type ChannelModeratorAddEvent struct {
@@ -527,6 +553,8 @@ type ChannelModeratorAddEvent struct {
 	UserLogin string `json:"user_login"`
 	// The display name of the new moderator.
 	UserName string `json:"user_name"`
+
+	decodedVersion
 }
 
 type ChannelModeratorRemoveEvent struct {
@@ -542,6 +570,8 @@ type ChannelModeratorRemoveEvent struct {
 	UserLogin string `json:"user_login"`
 	// The display name of the removed moderator.
 	UserName string `json:"user_name"`
+
+	decodedVersion
 }
 
 type ChannelPollBeginEvent struct {
@@ -565,6 +595,8 @@ type ChannelPollBeginEvent struct {
 	StartedAt TimestampUTC `json:"started_at"`
 	// The time the poll will end.
 	EndsAt TimestampUTC `json:"ends_at"`
+
+	decodedVersion
 }
 
 type ChannelPollProgressEvent struct {
@@ -588,6 +620,8 @@ type ChannelPollProgressEvent struct {
 	StartedAt TimestampUTC `json:"started_at"`
 	// The time the poll will end.
 	EndsAt TimestampUTC `json:"ends_at"`
+
+	decodedVersion
 }
 
 type ChannelPollEndEvent struct {
@@ -613,6 +647,8 @@ type ChannelPollEndEvent struct {
 	StartedAt TimestampUTC `json:"started_at"`
 	// The time the poll ended.
 	EndedAt TimestampUTC `json:"ended_at"`
+
+	decodedVersion
 }
 
 type ChannelPredictionBeginEvent struct {
@@ -632,6 +668,8 @@ type ChannelPredictionBeginEvent struct {
 	StartedAt TimestampUTC `json:"started_at"`
 	// The time the prediction will automatically lock.
 	LocksAt TimestampUTC `json:"locks_at"`
+
+	decodedVersion
 }
 
 type ChannelPredictionProgressEvent struct {
@@ -651,6 +689,8 @@ type ChannelPredictionProgressEvent struct {
 	StartedAt TimestampUTC `json:"started_at"`
 	// The time the Channel prediction will automatically lock.
 	LocksAt TimestampUTC `json:"locks_at"`
+
+	decodedVersion
 }
 
 type ChannelPredictionLockEvent struct {
@@ -670,6 +710,8 @@ type ChannelPredictionLockEvent struct {
 	StartedAt TimestampUTC `json:"started_at"`
 	// The time the prediction was locked.
 	LockedAt TimestampUTC `json:"locked_at"`
+
+	decodedVersion
 }
 
 type ChannelPredictionEndEvent struct {
@@ -693,6 +735,8 @@ type ChannelPredictionEndEvent struct {
 	StartedAt TimestampUTC `json:"started_at"`
 	// The time the prediction ended.
 	EndedAt TimestampUTC `json:"ended_at"`
+
+	decodedVersion
 }
 
 type ChannelRaidEvent struct {
@@ -710,6 +754,8 @@ type ChannelRaidEvent struct {
 	ToBroadcasterUserName string `json:"to_broadcaster_user_name"`
 	// The number of viewers in the raid.
 	Viewers int `json:"viewers"`
+
+	decodedVersion
 }
 
 type ChannelPointsCustomRewardRedemptionAddEvent struct {
@@ -735,6 +781,8 @@ type ChannelPointsCustomRewardRedemptionAddEvent struct {
 	Reward ChannelPointsCustomEventReward `json:"reward"`
 	// RFC3339 timestamp of when the reward was redeemed.
 	RedeemedAt TimestampUTC `json:"redeemed_at"`
+
+	decodedVersion
 }
 
 type ChannelPointsCustomRewardRedemptionUpdateEvent struct {
@@ -760,6 +808,8 @@ type ChannelPointsCustomRewardRedemptionUpdateEvent struct {
 	Reward ChannelPointsCustomEventReward `json:"reward"`
 	// RFC3339 timestamp of when the reward was redeemed.
 	RedeemedAt TimestampUTC `json:"redeemed_at"`
+
+	decodedVersion
 }
 
 type ChannelPointsAutomaticRewardRedemptionAddEvent struct {
@@ -819,6 +869,8 @@ type UserAuthorizationRevokeEvent struct {
 	UserLogin string `json:"user_login,omitempty"`
 	// The user display name for the user who has revoked authorization for your client id. Not presented if the user no longer exists.
 	UserName string `json:"user_name,omitempty"`
+
+	decodedVersion
 }
 
 type ChannelPointsCustomRewardAddEvent struct {
@@ -863,6 +915,8 @@ type ChannelPointsCustomRewardAddEvent struct {
 	// The number of redemptions redeemed during the current live stream. Counts against the max_per_stream limit.
 	// Not presented if the broadcasters stream isn’t live or max_per_stream isn’t enabled.
 	RedemptionsRedeemedCurrentStream int `json:"redemptions_redeemed_current_stream"`
+
+	decodedVersion
 }
 
 type ChannelPointsCustomRewardUpdateEvent struct {
@@ -907,6 +961,8 @@ type ChannelPointsCustomRewardUpdateEvent struct {
 	// The number of redemptions redeemed during the current live stream. Counts against the max_per_stream limit.
 	// Not presented if the broadcasters stream isn’t live or max_per_stream isn’t enabled.
 	RedemptionsRedeemedCurrentStream int `json:"redemptions_redeemed_current_stream,omitempty"`
+
+	decodedVersion
 }
 
 type ChannelPointsCustomRewardRemoveEvent struct {
@@ -952,6 +1008,8 @@ type ChannelPointsCustomRewardRemoveEvent struct {
 	// Counts against the max_per_stream limit.
 	// Not presented if the broadcasters stream isn’t live or max_per_stream isn’t enabled.
 	RedemptionsRedeemedCurrentStream int `json:"redemptions_redeemed_current_stream,omitempty"`
+
+	decodedVersion
 }
 
 type StreamOnlineEvent struct {
@@ -967,6 +1025,8 @@ type StreamOnlineEvent struct {
 	Type StreamType `json:"type"`
 	// The timestamp at which the stream went online at.
 	StartedAt TimestampUTC `json:"started_at"`
+
+	decodedVersion
 }
 
 type StreamOfflineEvent struct {
@@ -976,6 +1036,8 @@ type StreamOfflineEvent struct {
 	BroadcasterUserLogin string `json:"broadcaster_user_login"`
 	// The broadcaster’s user display name.
 	BroadcasterUserName string `json:"broadcaster_user_name"`
+
+	decodedVersion
 }
 
 type ChannelSubscribeEvent struct {
@@ -995,6 +1057,8 @@ type ChannelSubscribeEvent struct {
 	Tier SubscriptionTier `json:"tier"`
 	// Whether the subscription is a gift.
 	IsGift bool `json:"is_gift"`
+
+	decodedVersion
 }
 
 type ChannelSubscriptionMessageEvent struct {
@@ -1021,6 +1085,8 @@ type ChannelSubscriptionMessageEvent struct {
 	StreakMonths int `json:"streak_months,omitempty"`
 	// The month duration of the subscription.
 	DurationMonths int `json:"duration_months"`
+
+	decodedVersion
 }
 
 type ChannelSubscriptionEndEvent struct {
@@ -1040,6 +1106,8 @@ type ChannelSubscriptionEndEvent struct {
 	Tier SubscriptionTier `json:"tier"`
 	// Whether the subscription was a gift.
 	IsGift bool `json:"is_gift"`
+
+	decodedVersion
 }
 
 type ChannelSubscriptionGiftEvent struct {
@@ -1064,6 +1132,8 @@ type ChannelSubscriptionGiftEvent struct {
 	CumulativeTotal int `json:"cumulative_total,omitempty"`
 	// Whether the subscription gift was anonymous.
 	IsAnonymous bool `json:"is_anonymous"`
+
+	decodedVersion
 }
 
 type ChannelUnbanRequestCreateEvent struct {
@@ -1085,6 +1155,8 @@ type ChannelUnbanRequestCreateEvent struct {
 	Text string `json:"text"`
 	// The UTC timestamp (in RFC3339 format) of when the unban request was created.
 	CreatedAt TimestampUTC `json:"created_at"`
+
+	decodedVersion
 }
 
 type ChannelUnbanRequestResolveEvent struct {
@@ -1112,6 +1184,8 @@ type ChannelUnbanRequestResolveEvent struct {
 	ResolutionText string `json:"resolution_text,omitempty"`
 	// Dictates whether the unban request was approved or denied.
 	Status ChannelUnbanRequestResolveEventStatus `json:"status"`
+
+	decodedVersion
 }
 
 type UserUpdateEvent struct {
@@ -1130,6 +1204,8 @@ type UserUpdateEvent struct {
 	EmailVerified bool `json:"email_verified"`
 	// The user’s description.
 	Description string `json:"description"`
+
+	decodedVersion
 }
 
 type ChannelVipAddEvent struct {
@@ -1145,6 +1221,8 @@ type ChannelVipAddEvent struct {
 	BroadcasterUserLogin string `json:"broadcaster_user_login"`
 	// The broadcaster display name.
 	BroadcasterUserName string `json:"broadcaster_user_name"`
+
+	decodedVersion
 }
 
 type ChannelVipRemoveEvent struct {
@@ -1160,6 +1238,8 @@ type ChannelVipRemoveEvent struct {
 	BroadcasterUserLogin string `json:"broadcaster_user_login"`
 	// The broadcaster display name.
 	BroadcasterUserName string `json:"broadcaster_user_name"`
+
+	decodedVersion
 }
 
 type ChannelChatMessageDeleteEvent struct {
@@ -1177,4 +1257,6 @@ type ChannelChatMessageDeleteEvent struct {
 	TargetUserLogin string `json:"target_user_login"`
 	// A UUID that identifies the message that was removed.
 	MessageId string `json:"message_id"`
+
+	decodedVersion
 }
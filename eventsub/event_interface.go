@@ -0,0 +1,647 @@
+package eventsub
+
+// Event is a decoded EventSub event, it's implemented by every event defined in the library.
+type Event interface {
+	// EventType returns type of the event.
+	EventType() EventType
+	// Version returns version of the event payload. Go type of the event can be used for several versions (e.g. payload
+	// of a version that is not registered is decoded to it, see RegisterEvent), so version of the decoded payload is
+	// returned, or the latest registered one if the event is not decoded by the client.
+	Version() string
+	// BroadcasterId returns ID of the broadcaster whose channel the event relates to, or empty string if the event is not
+	// related to a channel (e.g. UserUpdateEvent). For ChannelRaidEvent it's ID of the broadcaster that is being raided.
+	BroadcasterId() string
+}
+
+var (
+	_ Event = AutomodMessageHoldEvent{}
+	_ Event = AutomodMessageHoldEventV2{}
+	_ Event = AutomodMessageUpdateEvent{}
+	_ Event = AutomodMessageUpdateEventV2{}
+	_ Event = AutomodSettingsUpdateEvent{}
+	_ Event = AutomodTermsUpdateEvent{}
+	_ Event = ChannelBitsUseEvent{}
+	_ Event = ChannelUpdateEvent{}
+	_ Event = ChannelFollowEvent{}
+	_ Event = ChannelAdBreakBeginEvent{}
+	_ Event = ChannelChatClearEvent{}
+	_ Event = ChannelChatClearUserMessagesEvent{}
+	_ Event = ChannelChatMessageEvent{}
+	_ Event = ConduitShardDisabledEvent{}
+	_ Event = ChannelBanEvent{}
+	_ Event = ChannelUnbanEvent{}
+	_ Event = ChannelChatNotificationEvent{}
+	_ Event = ChannelModeratorAddEvent{}
+	_ Event = ChannelModeratorRemoveEvent{}
+	_ Event = ChannelPollBeginEvent{}
+	_ Event = ChannelPollProgressEvent{}
+	_ Event = ChannelPollEndEvent{}
+	_ Event = ChannelPredictionBeginEvent{}
+	_ Event = ChannelPredictionProgressEvent{}
+	_ Event = ChannelPredictionLockEvent{}
+	_ Event = ChannelPredictionEndEvent{}
+	_ Event = ChannelRaidEvent{}
+	_ Event = ChannelPointsCustomRewardRedemptionAddEvent{}
+	_ Event = ChannelPointsCustomRewardRedemptionUpdateEvent{}
+	_ Event = ChannelPointsAutomaticRewardRedemptionAddEvent{}
+	_ Event = ChannelPointsAutomaticRewardRedemptionAddEventV2{}
+	_ Event = UserAuthorizationRevokeEvent{}
+	_ Event = ChannelPointsCustomRewardAddEvent{}
+	_ Event = ChannelPointsCustomRewardUpdateEvent{}
+	_ Event = ChannelPointsCustomRewardRemoveEvent{}
+	_ Event = StreamOfflineEvent{}
+	_ Event = StreamOnlineEvent{}
+	_ Event = ChannelSubscribeEvent{}
+	_ Event = ChannelSubscriptionEndEvent{}
+	_ Event = ChannelSubscriptionMessageEvent{}
+	_ Event = ChannelSubscriptionGiftEvent{}
+	_ Event = ChannelUnbanRequestCreateEvent{}
+	_ Event = ChannelUnbanRequestResolveEvent{}
+	_ Event = UserUpdateEvent{}
+	_ Event = ChannelVipAddEvent{}
+	_ Event = ChannelVipRemoveEvent{}
+	_ Event = ChannelChatMessageDeleteEvent{}
+)
+
+// decodedVersion is a version of the decoded event payload. It's embedded into events that payloads of several
+// versions are decoded to, so their Version returns the actual version.
+type decodedVersion struct {
+	version string
+}
+
+func (dv *decodedVersion) setVersion(version string) {
+	dv.version = version
+}
+
+// versionOr returns version of the decoded payload, or the provided version if the event is not decoded by the client.
+func (dv decodedVersion) versionOr(version string) string {
+	if dv.version != "" {
+		return dv.version
+	}
+
+	return version
+}
+
+func (e AutomodMessageHoldEvent) EventType() EventType {
+	return EventTypeAutomodMessageHold
+}
+
+func (e AutomodMessageHoldEvent) Version() string {
+	return "1"
+}
+
+func (e AutomodMessageHoldEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e AutomodMessageHoldEventV2) EventType() EventType {
+	return EventTypeAutomodMessageHold
+}
+
+func (e AutomodMessageHoldEventV2) Version() string {
+	return "2"
+}
+
+func (e AutomodMessageHoldEventV2) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e AutomodMessageUpdateEvent) EventType() EventType {
+	return EventTypeAutomodMessageUpdate
+}
+
+func (e AutomodMessageUpdateEvent) Version() string {
+	return "1"
+}
+
+func (e AutomodMessageUpdateEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e AutomodMessageUpdateEventV2) EventType() EventType {
+	return EventTypeAutomodMessageUpdate
+}
+
+func (e AutomodMessageUpdateEventV2) Version() string {
+	return "2"
+}
+
+func (e AutomodMessageUpdateEventV2) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e AutomodSettingsUpdateEvent) EventType() EventType {
+	return EventTypeAutomodSettingsUpdate
+}
+
+func (e AutomodSettingsUpdateEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e AutomodSettingsUpdateEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e AutomodTermsUpdateEvent) EventType() EventType {
+	return EventTypeAutomodTermsUpdate
+}
+
+func (e AutomodTermsUpdateEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e AutomodTermsUpdateEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelBitsUseEvent) EventType() EventType {
+	return EventTypeChannelBitsUse
+}
+
+func (e ChannelBitsUseEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelBitsUseEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelUpdateEvent) EventType() EventType {
+	return EventTypeChannelUpdate
+}
+
+func (e ChannelUpdateEvent) Version() string {
+	return e.versionOr("2")
+}
+
+func (e ChannelUpdateEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelFollowEvent) EventType() EventType {
+	return EventTypeChannelFollow
+}
+
+func (e ChannelFollowEvent) Version() string {
+	return e.versionOr("2")
+}
+
+func (e ChannelFollowEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelAdBreakBeginEvent) EventType() EventType {
+	return EventTypeChannelAdBreakBegin
+}
+
+func (e ChannelAdBreakBeginEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelAdBreakBeginEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelChatClearEvent) EventType() EventType {
+	return EventTypeChannelChatClear
+}
+
+func (e ChannelChatClearEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelChatClearEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelChatClearUserMessagesEvent) EventType() EventType {
+	return EventTypeChannelChatClearUserMessages
+}
+
+func (e ChannelChatClearUserMessagesEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelChatClearUserMessagesEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelChatMessageEvent) EventType() EventType {
+	return EventTypeChannelChatMessage
+}
+
+func (e ChannelChatMessageEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelChatMessageEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ConduitShardDisabledEvent) EventType() EventType {
+	return EventTypeConduitShardDisabled
+}
+
+func (e ConduitShardDisabledEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ConduitShardDisabledEvent) BroadcasterId() string {
+	return ""
+}
+
+func (e ChannelBanEvent) EventType() EventType {
+	return EventTypeChannelBan
+}
+
+func (e ChannelBanEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelBanEvent) BroadcasterId() string {
+	return e.BroadcasterUserID
+}
+
+func (e ChannelUnbanEvent) EventType() EventType {
+	return EventTypeChannelUnban
+}
+
+func (e ChannelUnbanEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelUnbanEvent) BroadcasterId() string {
+	return e.BroadcasterUserID
+}
+
+func (e ChannelChatNotificationEvent) EventType() EventType {
+	return EventTypeChannelChatNotification
+}
+
+func (e ChannelChatNotificationEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelChatNotificationEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelModeratorAddEvent) EventType() EventType {
+	return EventTypeChannelModeratorAdd
+}
+
+func (e ChannelModeratorAddEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelModeratorAddEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelModeratorRemoveEvent) EventType() EventType {
+	return EventTypeChannelModeratorRemove
+}
+
+func (e ChannelModeratorRemoveEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelModeratorRemoveEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPollBeginEvent) EventType() EventType {
+	return EventTypeChannelPollBegin
+}
+
+func (e ChannelPollBeginEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPollBeginEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPollProgressEvent) EventType() EventType {
+	return EventTypeChannelPollProgress
+}
+
+func (e ChannelPollProgressEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPollProgressEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPollEndEvent) EventType() EventType {
+	return EventTypeChannelPollEnd
+}
+
+func (e ChannelPollEndEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPollEndEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPredictionBeginEvent) EventType() EventType {
+	return EventTypeChannelPredictionBegin
+}
+
+func (e ChannelPredictionBeginEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPredictionBeginEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPredictionProgressEvent) EventType() EventType {
+	return EventTypeChannelPredictionProgress
+}
+
+func (e ChannelPredictionProgressEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPredictionProgressEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPredictionLockEvent) EventType() EventType {
+	return EventTypeChannelPredictionLock
+}
+
+func (e ChannelPredictionLockEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPredictionLockEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPredictionEndEvent) EventType() EventType {
+	return EventTypeChannelPredictionEnd
+}
+
+func (e ChannelPredictionEndEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPredictionEndEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelRaidEvent) EventType() EventType {
+	return EventTypeChannelRaid
+}
+
+func (e ChannelRaidEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelRaidEvent) BroadcasterId() string {
+	return e.ToBroadcasterUserId
+}
+
+func (e ChannelPointsCustomRewardRedemptionAddEvent) EventType() EventType {
+	return EventTypeChannelPointsCustomRewardRedemptionAdd
+}
+
+func (e ChannelPointsCustomRewardRedemptionAddEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPointsCustomRewardRedemptionAddEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPointsCustomRewardRedemptionUpdateEvent) EventType() EventType {
+	return EventTypeChannelPointsCustomRewardRedemptionUpdate
+}
+
+func (e ChannelPointsCustomRewardRedemptionUpdateEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPointsCustomRewardRedemptionUpdateEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPointsAutomaticRewardRedemptionAddEvent) EventType() EventType {
+	return EventTypeChannelPointsAutomaticRewardRedemptionAdd
+}
+
+func (e ChannelPointsAutomaticRewardRedemptionAddEvent) Version() string {
+	return "1"
+}
+
+func (e ChannelPointsAutomaticRewardRedemptionAddEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPointsAutomaticRewardRedemptionAddEventV2) EventType() EventType {
+	return EventTypeChannelPointsAutomaticRewardRedemptionAdd
+}
+
+func (e ChannelPointsAutomaticRewardRedemptionAddEventV2) Version() string {
+	return "2"
+}
+
+func (e ChannelPointsAutomaticRewardRedemptionAddEventV2) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e UserAuthorizationRevokeEvent) EventType() EventType {
+	return EventTypeUserAuthorizationRevoke
+}
+
+func (e UserAuthorizationRevokeEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e UserAuthorizationRevokeEvent) BroadcasterId() string {
+	return ""
+}
+
+func (e ChannelPointsCustomRewardAddEvent) EventType() EventType {
+	return EventTypeChannelPointsRewardAdd
+}
+
+func (e ChannelPointsCustomRewardAddEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPointsCustomRewardAddEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPointsCustomRewardUpdateEvent) EventType() EventType {
+	return EventTypeChannelPointsRewardUpdate
+}
+
+func (e ChannelPointsCustomRewardUpdateEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPointsCustomRewardUpdateEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelPointsCustomRewardRemoveEvent) EventType() EventType {
+	return EventTypeChannelPointsRewardRemove
+}
+
+func (e ChannelPointsCustomRewardRemoveEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelPointsCustomRewardRemoveEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e StreamOfflineEvent) EventType() EventType {
+	return EventTypeStreamOffline
+}
+
+func (e StreamOfflineEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e StreamOfflineEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e StreamOnlineEvent) EventType() EventType {
+	return EventTypeStreamOnline
+}
+
+func (e StreamOnlineEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e StreamOnlineEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelSubscribeEvent) EventType() EventType {
+	return EventTypeChannelSubscribe
+}
+
+func (e ChannelSubscribeEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelSubscribeEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelSubscriptionEndEvent) EventType() EventType {
+	return EventTypeChannelSubscriptionEnd
+}
+
+func (e ChannelSubscriptionEndEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelSubscriptionEndEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelSubscriptionMessageEvent) EventType() EventType {
+	return EventTypeChannelSubscriptionMessage
+}
+
+func (e ChannelSubscriptionMessageEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelSubscriptionMessageEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelSubscriptionGiftEvent) EventType() EventType {
+	return EventTypeChannelSubscriptionGift
+}
+
+func (e ChannelSubscriptionGiftEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelSubscriptionGiftEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelUnbanRequestCreateEvent) EventType() EventType {
+	return EventTypeChannelUnbanRequestCreate
+}
+
+func (e ChannelUnbanRequestCreateEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelUnbanRequestCreateEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelUnbanRequestResolveEvent) EventType() EventType {
+	return EventTypeChannelUnbanRequestResolve
+}
+
+func (e ChannelUnbanRequestResolveEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelUnbanRequestResolveEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e UserUpdateEvent) EventType() EventType {
+	return EventTypeUserUpdate
+}
+
+func (e UserUpdateEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e UserUpdateEvent) BroadcasterId() string {
+	return ""
+}
+
+func (e ChannelVipAddEvent) EventType() EventType {
+	return EventTypeChannelVipAdd
+}
+
+func (e ChannelVipAddEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelVipAddEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelVipRemoveEvent) EventType() EventType {
+	return EventTypeChannelVipRemove
+}
+
+func (e ChannelVipRemoveEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelVipRemoveEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
+
+func (e ChannelChatMessageDeleteEvent) EventType() EventType {
+	return EventTypeChannelMessageDelete
+}
+
+func (e ChannelChatMessageDeleteEvent) Version() string {
+	return e.versionOr("1")
+}
+
+func (e ChannelChatMessageDeleteEvent) BroadcasterId() string {
+	return e.BroadcasterUserId
+}
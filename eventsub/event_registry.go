@@ -0,0 +1,202 @@
+package eventsub
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/twirapp/twitchy/internal/json"
+)
+
+// ErrEventAlreadyRegistered indicates that event with the same type and version is already registered with another Go
+// type.
+var ErrEventAlreadyRegistered = errors.New("event is already registered with another type")
+
+// eventKey identifies event by its type and version.
+type eventKey struct {
+	eventType EventType
+	version   string
+}
+
+// eventDescriptor describes Go type of the event.
+type eventDescriptor struct {
+	goType reflect.Type
+	// decode parses payload of the event with the provided version.
+	decode func(payload []byte, version string) (any, error)
+}
+
+// versionedEvent is implemented by events whose Go type is used for several versions of the event, so version of the
+// payload is set to them when they are decoded.
+type versionedEvent interface {
+	setVersion(version string)
+}
+
+// eventRegistry maps event types and versions to Go types of the events.
+type eventRegistry struct {
+	mu     sync.RWMutex
+	byKey  map[eventKey]eventDescriptor
+	byType map[reflect.Type][]eventKey
+	// fallbacks maps event types to descriptors of events that versions which are not registered are parsed to.
+	fallbacks map[EventType]eventDescriptor
+}
+
+var registry = eventRegistry{
+	byKey:     make(map[eventKey]eventDescriptor),
+	byType:    make(map[reflect.Type][]eventKey),
+	fallbacks: make(map[EventType]eventDescriptor),
+}
+
+// RegisterEvent registers Go type of the event with the provided type and version, so handlers of this type can be
+// registered with On and OnContext (e.g. for a new version of the event that is not defined in the library yet). Event
+// payload is parsed as JSON to the Go type.
+//
+// Registering the same event with the same Go type again does nothing, while registering it with another Go type
+// returns ErrEventAlreadyRegistered.
+//
+// Versions of library events that are not registered are parsed to the library type if all registered versions of the
+// event share it, otherwise they are undefined (see OnUndefinedEvent).
+func RegisterEvent[Event any](eventType EventType, version string) error {
+	return registry.register(eventKey{eventType: eventType, version: version}, eventDescriptor{
+		goType: reflect.TypeFor[Event](),
+		decode: func(payload []byte, version string) (any, error) {
+			var event Event
+
+			if err := json.Unmarshal(payload, &event); err != nil {
+				return nil, err
+			}
+
+			if versioned, ok := any(&event).(versionedEvent); ok {
+				versioned.setVersion(version)
+			}
+
+			return event, nil
+		},
+	})
+}
+
+func (er *eventRegistry) register(key eventKey, descriptor eventDescriptor) error {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+
+	if registered, ok := er.byKey[key]; ok {
+		if registered.goType == descriptor.goType {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s v%s as %s", ErrEventAlreadyRegistered, key.eventType, key.version, registered.goType)
+	}
+
+	er.byKey[key] = descriptor
+	er.byType[descriptor.goType] = append(er.byType[descriptor.goType], key)
+
+	return nil
+}
+
+// lookup returns descriptor of the event with the provided type and version, or descriptor of the fallback event if
+// the version is not registered.
+func (er *eventRegistry) lookup(key eventKey) (eventDescriptor, bool) {
+	er.mu.RLock()
+	defer er.mu.RUnlock()
+
+	if descriptor, ok := er.byKey[key]; ok {
+		return descriptor, true
+	}
+
+	descriptor, ok := er.fallbacks[key.eventType]
+	return descriptor, ok
+}
+
+// keysOf returns keys of the events registered with the provided Go type.
+func (er *eventRegistry) keysOf(goType reflect.Type) []eventKey {
+	er.mu.RLock()
+	defer er.mu.RUnlock()
+
+	return er.byType[goType]
+}
+
+// setFallbacks makes each event type that is registered with a single Go type fall back to it for versions that are
+// not registered. Library dispatched such events regardless of their version before versions were registered, so new
+// versions of them keep reaching their handlers.
+func (er *eventRegistry) setFallbacks() {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+
+	ambiguous := make(map[EventType]struct{})
+
+	for key, descriptor := range er.byKey {
+		if _, ok := ambiguous[key.eventType]; ok {
+			continue
+		}
+
+		fallback, ok := er.fallbacks[key.eventType]
+		if ok && fallback.goType != descriptor.goType {
+			delete(er.fallbacks, key.eventType)
+			ambiguous[key.eventType] = struct{}{}
+
+			continue
+		}
+
+		er.fallbacks[key.eventType] = descriptor
+	}
+}
+
+// registerBuiltinEvent registers event defined in the library and panics if it fails, as it's a programming error.
+func registerBuiltinEvent[Event any](eventType EventType, version string) {
+	if err := RegisterEvent[Event](eventType, version); err != nil {
+		panic(err)
+	}
+}
+
+func init() {
+	registerBuiltinEvent[AutomodMessageHoldEvent](EventTypeAutomodMessageHold, "1")
+	registerBuiltinEvent[AutomodMessageHoldEventV2](EventTypeAutomodMessageHold, "2")
+	registerBuiltinEvent[AutomodMessageUpdateEvent](EventTypeAutomodMessageUpdate, "1")
+	registerBuiltinEvent[AutomodMessageUpdateEventV2](EventTypeAutomodMessageUpdate, "2")
+	registerBuiltinEvent[AutomodSettingsUpdateEvent](EventTypeAutomodSettingsUpdate, "1")
+	registerBuiltinEvent[AutomodTermsUpdateEvent](EventTypeAutomodTermsUpdate, "1")
+	registerBuiltinEvent[ChannelBitsUseEvent](EventTypeChannelBitsUse, "1")
+	registerBuiltinEvent[ChannelUpdateEvent](EventTypeChannelUpdate, "1")
+	registerBuiltinEvent[ChannelUpdateEvent](EventTypeChannelUpdate, "2")
+	registerBuiltinEvent[ChannelFollowEvent](EventTypeChannelFollow, "2")
+	registerBuiltinEvent[ChannelAdBreakBeginEvent](EventTypeChannelAdBreakBegin, "1")
+	registerBuiltinEvent[ChannelChatClearEvent](EventTypeChannelChatClear, "1")
+	registerBuiltinEvent[ChannelChatClearUserMessagesEvent](EventTypeChannelChatClearUserMessages, "1")
+	registerBuiltinEvent[ChannelChatMessageEvent](EventTypeChannelChatMessage, "1")
+	registerBuiltinEvent[ConduitShardDisabledEvent](EventTypeConduitShardDisabled, "1")
+	registerBuiltinEvent[ChannelBanEvent](EventTypeChannelBan, "1")
+	registerBuiltinEvent[ChannelUnbanEvent](EventTypeChannelUnban, "1")
+	registerBuiltinEvent[ChannelChatNotificationEvent](EventTypeChannelChatNotification, "1")
+	registerBuiltinEvent[ChannelModeratorAddEvent](EventTypeChannelModeratorAdd, "1")
+	registerBuiltinEvent[ChannelModeratorRemoveEvent](EventTypeChannelModeratorRemove, "1")
+	registerBuiltinEvent[ChannelPollBeginEvent](EventTypeChannelPollBegin, "1")
+	registerBuiltinEvent[ChannelPollProgressEvent](EventTypeChannelPollProgress, "1")
+	registerBuiltinEvent[ChannelPollEndEvent](EventTypeChannelPollEnd, "1")
+	registerBuiltinEvent[ChannelPredictionBeginEvent](EventTypeChannelPredictionBegin, "1")
+	registerBuiltinEvent[ChannelPredictionProgressEvent](EventTypeChannelPredictionProgress, "1")
+	registerBuiltinEvent[ChannelPredictionLockEvent](EventTypeChannelPredictionLock, "1")
+	registerBuiltinEvent[ChannelPredictionEndEvent](EventTypeChannelPredictionEnd, "1")
+	registerBuiltinEvent[ChannelRaidEvent](EventTypeChannelRaid, "1")
+	registerBuiltinEvent[ChannelPointsCustomRewardRedemptionAddEvent](EventTypeChannelPointsCustomRewardRedemptionAdd, "1")
+	registerBuiltinEvent[ChannelPointsCustomRewardRedemptionUpdateEvent](EventTypeChannelPointsCustomRewardRedemptionUpdate, "1")
+	registerBuiltinEvent[ChannelPointsAutomaticRewardRedemptionAddEvent](EventTypeChannelPointsAutomaticRewardRedemptionAdd, "1")
+	registerBuiltinEvent[ChannelPointsAutomaticRewardRedemptionAddEventV2](EventTypeChannelPointsAutomaticRewardRedemptionAdd, "2")
+	registerBuiltinEvent[UserAuthorizationRevokeEvent](EventTypeUserAuthorizationRevoke, "1")
+	registerBuiltinEvent[ChannelPointsCustomRewardAddEvent](EventTypeChannelPointsRewardAdd, "1")
+	registerBuiltinEvent[ChannelPointsCustomRewardUpdateEvent](EventTypeChannelPointsRewardUpdate, "1")
+	registerBuiltinEvent[ChannelPointsCustomRewardRemoveEvent](EventTypeChannelPointsRewardRemove, "1")
+	registerBuiltinEvent[StreamOfflineEvent](EventTypeStreamOffline, "1")
+	registerBuiltinEvent[StreamOnlineEvent](EventTypeStreamOnline, "1")
+	registerBuiltinEvent[ChannelSubscribeEvent](EventTypeChannelSubscribe, "1")
+	registerBuiltinEvent[ChannelSubscriptionEndEvent](EventTypeChannelSubscriptionEnd, "1")
+	registerBuiltinEvent[ChannelSubscriptionMessageEvent](EventTypeChannelSubscriptionMessage, "1")
+	registerBuiltinEvent[ChannelSubscriptionGiftEvent](EventTypeChannelSubscriptionGift, "1")
+	registerBuiltinEvent[ChannelUnbanRequestCreateEvent](EventTypeChannelUnbanRequestCreate, "1")
+	registerBuiltinEvent[ChannelUnbanRequestResolveEvent](EventTypeChannelUnbanRequestResolve, "1")
+	registerBuiltinEvent[UserUpdateEvent](EventTypeUserUpdate, "1")
+	registerBuiltinEvent[ChannelVipAddEvent](EventTypeChannelVipAdd, "1")
+	registerBuiltinEvent[ChannelVipRemoveEvent](EventTypeChannelVipRemove, "1")
+	registerBuiltinEvent[ChannelChatMessageDeleteEvent](EventTypeChannelMessageDelete, "1")
+
+	registry.setFallbacks()
+}
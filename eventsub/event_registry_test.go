@@ -0,0 +1,91 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRegistryFallbackForUnregisteredVersion(t *testing.T) {
+	c := &callback[struct{}]{synchronous: true}
+
+	var banned []string
+
+	On(c, func(event ChannelBanEvent, _ struct{}) {
+		banned = append(banned, event.UserId)
+	})
+
+	rawEvent := RawEvent{Event: []byte(`{"user_id":"1"}`)}
+
+	if err := c.runEventCallback(context.Background(), EventTypeChannelBan, "99", rawEvent, struct{}{}); err != nil {
+		t.Fatalf("run callback: %v", err)
+	}
+
+	if len(banned) != 1 || banned[0] != "1" {
+		t.Fatalf("expected handler to receive event of unregistered version, got %v", banned)
+	}
+}
+
+func TestRegistryUnregisteredVersionOfVersionedType(t *testing.T) {
+	c := &callback[struct{}]{synchronous: true}
+
+	On(c, func(AutomodMessageHoldEvent, struct{}) {
+		t.Error("handler of v1 must not receive event of unregistered version")
+	})
+
+	rawEvent := RawEvent{Event: []byte(`{}`)}
+
+	err := c.runEventCallback(context.Background(), EventTypeAutomodMessageHold, "99", rawEvent, struct{}{})
+	if !errors.Is(err, ErrUndefinedEventType) {
+		t.Fatalf("expected ErrUndefinedEventType, got %v", err)
+	}
+
+	var undefined int
+
+	c.OnUndefinedEvent(func(RawEvent, struct{}) {
+		undefined++
+	})
+
+	err = c.runEventCallback(context.Background(), EventTypeAutomodMessageHold, "99", rawEvent, struct{}{})
+	if err != nil {
+		t.Fatalf("run callback: %v", err)
+	}
+
+	if undefined != 1 {
+		t.Fatalf("expected OnUndefinedEvent to be called once, got %d", undefined)
+	}
+}
+
+type testRegistryEvent struct {
+	Value string `json:"value"`
+}
+
+func TestRegistryVersionRegisteredAfterHandler(t *testing.T) {
+	const eventType EventType = "test.registry.late_version"
+
+	if err := RegisterEvent[testRegistryEvent](eventType, "1"); err != nil {
+		t.Fatalf("register v1: %v", err)
+	}
+
+	c := &callback[struct{}]{synchronous: true}
+
+	var values []string
+
+	On(c, func(event testRegistryEvent, _ struct{}) {
+		values = append(values, event.Value)
+	})
+
+	if err := RegisterEvent[testRegistryEvent](eventType, "2"); err != nil {
+		t.Fatalf("register v2: %v", err)
+	}
+
+	rawEvent := RawEvent{Event: []byte(`{"value":"v2"}`)}
+
+	if err := c.runEventCallback(context.Background(), eventType, "2", rawEvent, struct{}{}); err != nil {
+		t.Fatalf("run callback: %v", err)
+	}
+
+	if len(values) != 1 || values[0] != "v2" {
+		t.Fatalf("expected handler to receive version registered after it, got %v", values)
+	}
+}
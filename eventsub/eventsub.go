@@ -32,8 +32,8 @@ func New(options ...Option) EventSub {
 }
 
 // Webhook returns new eventsub Webhook handler that implements http.Handler.
-func (es *EventSub) Webhook(secret []byte, verifySignature bool) (*Webhook, error) {
-	return newWebhook(secret, es.eventTracker, verifySignature)
+func (es *EventSub) Webhook(secret []byte, verifySignature bool, options ...WebhookOption) (*Webhook, error) {
+	return newWebhook(secret, es.eventTracker, verifySignature, options...)
 }
 
 // Websocket returns new eventsub Websocket client.
@@ -41,6 +41,11 @@ func (es *EventSub) Websocket(options ...WebsocketOption) *Websocket {
 	return newWebsocket(es.eventTracker, options...)
 }
 
+// WebsocketPool returns new eventsub WebsocketPool that spreads subscriptions across multiple Websocket clients.
+func (es *EventSub) WebsocketPool(options ...WebsocketPoolOption) *WebsocketPool {
+	return newWebsocketPool(es, options...)
+}
+
 // isExpiredMessage returns does eventsub message with provided timestamp is too old (expired) to process or not.
 //
 // According to the Twitch's documentation we should not process messages that are older than 10 minutes from the moment
@@ -15,3 +15,11 @@ type EventTracker interface {
 	// Track starts tracking of event with the provided identifier and returns if that event is already being tracked (duplicate or not).
 	Track(ctx context.Context, eventID string) (bool, error)
 }
+
+// Untracker is an EventTracker that can stop tracking of event, so its redelivery is not counted as duplicate. It's
+// used by webhook to untrack notification that was not accepted, as Twitch will redeliver it. Standard EventTracker
+// implementations implement it.
+type Untracker interface {
+	// Untrack stops tracking of event with the provided identifier.
+	Untrack(ctx context.Context, eventID string) error
+}
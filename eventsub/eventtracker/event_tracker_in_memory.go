@@ -13,7 +13,10 @@ type InMemoryEventTracker struct {
 	events shardedmap.ShardedMap[string, struct{}]
 }
 
-var _ EventTracker = (*InMemoryEventTracker)(nil)
+var (
+	_ EventTracker = (*InMemoryEventTracker)(nil)
+	_ Untracker    = (*InMemoryEventTracker)(nil)
+)
 
 func NewInMemoryEventTracker(ctx context.Context, options ...Option) *InMemoryEventTracker {
 	opt := option{
@@ -33,3 +36,8 @@ func (iet *InMemoryEventTracker) Track(_ context.Context, eventID string) (bool,
 	_, isDuplicate := iet.events.GetOrSet(eventID, struct{}{})
 	return isDuplicate, nil
 }
+
+func (iet *InMemoryEventTracker) Untrack(_ context.Context, eventID string) error {
+	iet.events.Delete(eventID)
+	return nil
+}
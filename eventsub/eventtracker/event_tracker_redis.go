@@ -20,7 +20,10 @@ type RedisEventTracker struct {
 	key      RedisKeyBuilder
 }
 
-var _ EventTracker = (*RedisEventTracker)(nil)
+var (
+	_ EventTracker = (*RedisEventTracker)(nil)
+	_ Untracker    = (*RedisEventTracker)(nil)
+)
 
 func NewRedisEventTracker(client *redis.Client, key RedisKeyBuilder, options ...Option) (RedisEventTracker, error) {
 	if key == nil {
@@ -53,3 +56,11 @@ func (ret RedisEventTracker) Track(ctx context.Context, eventID string) (bool, e
 	isDuplicate := !firstTimeSeen
 	return isDuplicate, nil
 }
+
+func (ret RedisEventTracker) Untrack(ctx context.Context, eventID string) error {
+	if err := ret.client.Del(ctx, ret.key(eventID)).Err(); err != nil {
+		return fmt.Errorf("del: %w", err)
+	}
+
+	return nil
+}
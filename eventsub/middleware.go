@@ -0,0 +1,151 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+)
+
+// ErrHandlerTimeout indicates that handler didn't finish in time set by TimeoutMiddleware.
+var ErrHandlerTimeout = errors.New("handler timed out")
+
+// Envelope is an event dispatched to the handler with type-erased access to its type, version, decoded event and
+// metadata (e.g. WebsocketNotificationMetadata or WebhookNotificationMetadata).
+type Envelope struct {
+	Type     EventType
+	Version  string
+	Event    any
+	Metadata any
+}
+
+// EnvelopeHandler is a type-erased handler of the dispatched event.
+type EnvelopeHandler func(context.Context, Envelope) error
+
+// Middleware wraps every event handler, so it can run code before and after it, or not run it at all.
+type Middleware func(next EnvelopeHandler) EnvelopeHandler
+
+// Use adds middlewares that wrap every event handler. Middlewares are applied in the order they are added, so the first
+// one is the outermost.
+func (c *callback[Metadata]) Use(middlewares ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Middlewares are copied on write, so they can be added while events are dispatched.
+	c.middlewares = append(c.middlewares[:len(c.middlewares):len(c.middlewares)], middlewares...)
+}
+
+// chain wraps handler with middlewares.
+func (c *callback[Metadata]) chain(handler EnvelopeHandler) EnvelopeHandler {
+	c.mu.RLock()
+	middlewares := c.middlewares
+	c.mu.RUnlock()
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// PanicError is an error returned by RecoveryMiddleware if handler panics.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+var _ error = (*PanicError)(nil)
+
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", pe.Value)
+}
+
+// RecoveryMiddleware returns middleware that recovers handler from panic and returns PanicError instead of crashing the
+// whole process.
+func RecoveryMiddleware() Middleware {
+	return func(next EnvelopeHandler) EnvelopeHandler {
+		return func(ctx context.Context, envelope Envelope) (err error) {
+			defer func() {
+				if value := recover(); value != nil {
+					err = &PanicError{
+						Value: value,
+						Stack: debug.Stack(),
+					}
+				}
+			}()
+
+			return next(ctx, envelope)
+		}
+	}
+}
+
+// TimeoutMiddleware returns middleware that cancels context of handler after the timeout and returns ErrHandlerTimeout
+// if handler didn't finish in time. Handler that ignores its context keeps running in background.
+func TimeoutMiddleware(timeout time.Duration) Middleware {
+	return func(next EnvelopeHandler) EnvelopeHandler {
+		return func(ctx context.Context, envelope Envelope) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			var (
+				done     = make(chan error, 1)
+				panicked = make(chan any, 1)
+			)
+
+			go func() {
+				// Panic is propagated to the caller goroutine, so outer middlewares (e.g. recovery) can handle it.
+				defer func() {
+					if value := recover(); value != nil {
+						panicked <- value
+					}
+				}()
+
+				done <- next(ctx, envelope)
+			}()
+
+			select {
+			case err := <-done:
+				return err
+			case value := <-panicked:
+				panic(value)
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %w", ErrHandlerTimeout, ctx.Err())
+			}
+		}
+	}
+}
+
+// LoggingMiddleware returns middleware that logs every handled event with its duration on debug level, and failed ones
+// on error level. Default logger is used if logger is nil.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next EnvelopeHandler) EnvelopeHandler {
+		return func(ctx context.Context, envelope Envelope) error {
+			start := time.Now()
+
+			err := next(ctx, envelope)
+
+			attrs := []slog.Attr{
+				slog.String("type", envelope.Type.String()),
+				slog.String("version", envelope.Version),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+				logger.LogAttrs(ctx, slog.LevelError, "eventsub handler failed", attrs...)
+
+				return err
+			}
+
+			logger.LogAttrs(ctx, slog.LevelDebug, "eventsub handler finished", attrs...)
+
+			return nil
+		}
+	}
+}
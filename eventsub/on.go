@@ -0,0 +1,44 @@
+package eventsub
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Registrar is a client that event handlers can be registered on, i.e. Websocket or Webhook.
+type Registrar[Metadata any] interface {
+	addHandler(goType reflect.Type, handler ContextHandler[any, Metadata]) func()
+}
+
+var (
+	_ Registrar[WebsocketNotificationMetadata] = (*Websocket)(nil)
+	_ Registrar[WebhookNotificationMetadata]   = (*Webhook)(nil)
+)
+
+// On registers handler of the event with the Go type, e.g. On[ChannelFollowEvent](websocket, handler), and returns
+// function that removes it. Handler is invoked for each event type and version registered with this Go type (see
+// RegisterEvent), including versions registered after the handler.
+//
+// On panics if Go type of the event is not registered, as it's a programming error.
+func On[Event, Metadata any](client Registrar[Metadata], handler Handler[Event, Metadata]) func() {
+	return OnContext(client, handler.withContext())
+}
+
+// OnContext is the same as On, but handler receives context and returns error.
+func OnContext[Event, Metadata any](client Registrar[Metadata], handler ContextHandler[Event, Metadata]) func() {
+	if handler == nil {
+		return func() {}
+	}
+
+	goType := reflect.TypeFor[Event]()
+
+	if len(registry.keysOf(goType)) == 0 {
+		panic(fmt.Sprintf("eventsub: event type %s is not registered", goType))
+	}
+
+	// Handler is stored by Go type, so event type and version are resolved to it when the event is dispatched.
+	return client.addHandler(goType, func(ctx context.Context, event any, metadata Metadata) error {
+		return handler(ctx, event.(Event), metadata)
+	})
+}
@@ -1,5 +1,29 @@
 package eventsub
 
+// Subscription statuses.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#get-eventsub-subscriptions.
+const (
+	SubscriptionStatusEnabled                            = "enabled"
+	SubscriptionStatusWebhookCallbackVerificationPending = "webhook_callback_verification_pending"
+	SubscriptionStatusWebhookCallbackVerificationFailed  = "webhook_callback_verification_failed"
+	SubscriptionStatusNotificationFailuresExceeded       = "notification_failures_exceeded"
+	SubscriptionStatusAuthorizationRevoked               = "authorization_revoked"
+	SubscriptionStatusModeratorRemoved                   = "moderator_removed"
+	SubscriptionStatusUserRemoved                        = "user_removed"
+	SubscriptionStatusChatUserBanned                     = "chat_user_banned"
+	SubscriptionStatusVersionRemoved                     = "version_removed"
+	SubscriptionStatusBetaMaintenance                    = "beta_maintenance"
+	SubscriptionStatusWebsocketDisconnected              = "websocket_disconnected"
+	SubscriptionStatusWebsocketFailedPingPong            = "websocket_failed_ping_pong"
+	SubscriptionStatusWebsocketReceivedInboundTraffic    = "websocket_received_inbound_traffic"
+	SubscriptionStatusWebsocketConnectionUnused          = "websocket_connection_unused"
+	SubscriptionStatusWebsocketInternalError             = "websocket_internal_error"
+	SubscriptionStatusWebsocketNetworkTimeout            = "websocket_network_timeout"
+	SubscriptionStatusWebsocketNetworkError              = "websocket_network_error"
+	SubscriptionStatusWebsocketFailedToReconnect         = "websocket_failed_to_reconnect"
+)
+
 type Subscription[C Condition, T Transport] struct {
 	Id        string       `json:"id"`
 	Status    string       `json:"status"`
@@ -5,20 +5,40 @@ type Transport interface {
 	transport()
 }
 
+// Transport methods supported by eventsub.
+const (
+	TransportMethodWebhook   = "webhook"
+	TransportMethodWebsocket = "websocket"
+	TransportMethodConduit   = "conduit"
+)
+
 type WebhookTransport struct {
-	Transport
-	Method   string `json:"method"`
-	Callback string `json:"callback"`
+	Transport `json:"-"`
+	Method    string `json:"method"`
+	Callback  string `json:"callback"`
+	// Secret is a secret used to verify the signature of notifications. It's only sent when creating a subscription
+	// and never returned by Twitch.
+	Secret string `json:"secret,omitempty"`
 }
 
 type ConduitTransport struct {
-	Transport
+	Transport `json:"-"`
 	Method    string `json:"method"`
 	ConduitId string `json:"conduit_id"`
 }
 
 type WebsocketTransport struct {
-	Transport
+	Transport `json:"-"`
 	Method    string `json:"method"`
 	SessionId string `json:"session_id"`
 }
+
+// AnyTransport is a transport of any method. Only fields related to the Method are set.
+type AnyTransport struct {
+	Transport `json:"-"`
+	Method    string `json:"method"`
+	Callback  string `json:"callback,omitempty"`
+	Secret    string `json:"secret,omitempty"`
+	SessionId string `json:"session_id,omitempty"`
+	ConduitId string `json:"conduit_id,omitempty"`
+}
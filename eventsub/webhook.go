@@ -1,6 +1,7 @@
 package eventsub
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -33,16 +34,27 @@ type Webhook struct {
 
 var _ http.Handler = (*Webhook)(nil)
 
-func newWebhook(secret []byte, eventTracker eventtracker.EventTracker, verifySignature bool) (*Webhook, error) {
+func newWebhook(
+	secret []byte,
+	eventTracker eventtracker.EventTracker,
+	verifySignature bool,
+	options ...WebhookOption,
+) (*Webhook, error) {
 	if !isValidWebhookSecret(secret) {
 		return nil, ErrInvalidWebhookSecret
 	}
 
-	return &Webhook{
+	wh := &Webhook{
 		eventTracker:              eventTracker,
 		secret:                    secret,
 		withSignatureVerification: verifySignature,
-	}, nil
+	}
+
+	for _, option := range options {
+		option(wh)
+	}
+
+	return wh, nil
 }
 
 func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -50,6 +62,12 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid method", http.StatusMethodNotAllowed)
 	}
 
+	// Twitch retries notification later if it's not accepted, so it's not lost on shutdown.
+	if wh.isClosed() {
+		http.Error(w, "webhook is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -79,7 +97,7 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	err, safe := isSafeMessage(
 		r.Context(),
-		wh.onDuplicate,
+		wh.getOnDuplicate(),
 		wh.eventTracker,
 		metadata,
 		metadata.MessageId,
@@ -95,7 +113,53 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	wh.handleNotification(w, metadata, body)
+	recorder := &webhookStatusRecorder{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+
+	wh.handleNotification(r.Context(), recorder, metadata, body)
+
+	// Twitch redelivers notification that is not accepted (e.g. synchronous handler failed), so redelivery must not
+	// be counted as duplicate.
+	if recorder.status >= http.StatusMultipleChoices {
+		wh.untrack(context.WithoutCancel(r.Context()), metadata)
+	}
+}
+
+// untrack stops tracking of the message if event tracker supports it. Error is reported to OnHandlerError callback.
+func (wh *Webhook) untrack(ctx context.Context, metadata WebhookNotificationMetadata) {
+	untracker, ok := wh.eventTracker.(eventtracker.Untracker)
+	if !ok {
+		return
+	}
+
+	if err := untracker.Untrack(ctx, metadata.MessageId); err != nil {
+		if onHandlerError := wh.getOnHandlerError(); onHandlerError != nil {
+			go onHandlerError(fmt.Errorf("untrack message: %w", err), metadata)
+		}
+	}
+}
+
+// webhookStatusRecorder is a http.ResponseWriter that records status code of the response.
+type webhookStatusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (wsr *webhookStatusRecorder) WriteHeader(status int) {
+	wsr.status = status
+	wsr.ResponseWriter.WriteHeader(status)
+}
+
+// Shutdown gracefully shuts down the handler: new notifications are rejected with 503 status code, so Twitch will
+// retry them later, and in-flight event handlers are waited to finish until the context is done. ShutdownError with a
+// number of abandoned handlers is returned if the context is done first.
+//
+// It doesn't shut down the HTTP server the handler is mounted on, so it should be called before (or along with)
+// http.Server.Shutdown.
+func (wh *Webhook) Shutdown(ctx context.Context) error {
+	return wh.drain(ctx)
 }
 
 // isValidSignature validates that provided request signature is valid based on the request body, message id and timestamp.
@@ -11,7 +11,7 @@ type WebhookNotificationMetadata struct {
 }
 
 type WebhookNotificationCondition struct {
-	Condition
+	Condition         `json:"-"`
 	BroadcasterUserId string `json:"broadcaster_user_id"`
 }
 
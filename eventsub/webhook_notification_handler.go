@@ -1,6 +1,7 @@
 package eventsub
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
@@ -14,12 +15,17 @@ type webhookRawEvent struct {
 }
 
 // handleNotification handles webhook notification sent by eventsub server.
-func (wh *Webhook) handleNotification(w http.ResponseWriter, metadata WebhookNotificationMetadata, body []byte) {
+func (wh *Webhook) handleNotification(
+	ctx context.Context,
+	w http.ResponseWriter,
+	metadata WebhookNotificationMetadata,
+	body []byte,
+) {
 	messageType := metadata.MessageType
 
 	switch messageType {
 	case "notification":
-		wh.handleEventNotification(w, metadata, body)
+		wh.handleEventNotification(ctx, w, metadata, body)
 	case "webhook_callback_verification":
 		wh.handleCallbackVerificationNotification(w, body)
 	case "revocation":
@@ -29,10 +35,17 @@ func (wh *Webhook) handleNotification(w http.ResponseWriter, metadata WebhookNot
 	}
 }
 
-// handleEventNotification handles event notification request.
+// handleEventNotification handles event notification request. If handlers run synchronously, error returned from
+// handler makes Webhook respond with non-2xx status code, so Twitch redelivers notification (see
+// WebhookNotificationMetadata.MessageRetry).
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/handling-webhook-events/#processing-an-event.
-func (wh *Webhook) handleEventNotification(w http.ResponseWriter, metadata WebhookNotificationMetadata, body []byte) {
+func (wh *Webhook) handleEventNotification(
+	ctx context.Context,
+	w http.ResponseWriter,
+	metadata WebhookNotificationMetadata,
+	body []byte,
+) {
 	var rawNotification webhookRawEvent
 
 	if err := json.Unmarshal(body, &rawNotification); err != nil {
@@ -45,12 +58,16 @@ func (wh *Webhook) handleEventNotification(w http.ResponseWriter, metadata Webho
 		Event:        rawNotification.Event,
 	}
 
-	if err := wh.callback.runEventCallback(metadata.SubscriptionType, metadata.SubscriptionVersion, rawEvent, metadata); err != nil {
+	err := wh.callback.runEventCallback(ctx, metadata.SubscriptionType, metadata.SubscriptionVersion, rawEvent, metadata)
+	if err != nil {
 		var status int
 
-		if errors.Is(err, ErrUndefinedEventType) {
+		switch {
+		case errors.Is(err, ErrUndefinedEventType):
 			status = http.StatusBadRequest
-		} else {
+		case errors.Is(err, ErrShuttingDown):
+			status = http.StatusServiceUnavailable
+		default:
 			status = http.StatusInternalServerError
 		}
 
@@ -65,7 +82,7 @@ func (wh *Webhook) handleEventNotification(w http.ResponseWriter, metadata Webho
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/handling-webhook-events/#revoking-your-subscription.
 func (wh *Webhook) handleRevocationNotification(w http.ResponseWriter, body []byte) {
-	if _, ok := runEventWebhookHandler(wh.onRevocation, w, body); !ok {
+	if _, ok := runEventWebhookHandler(wh.dispatch, wh.onRevocation, w, body); !ok {
 		return
 	}
 
@@ -76,7 +93,7 @@ func (wh *Webhook) handleRevocationNotification(w http.ResponseWriter, body []by
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/handling-webhook-events/#responding-to-a-challenge-request.
 func (wh *Webhook) handleCallbackVerificationNotification(w http.ResponseWriter, body []byte) {
-	notification, ok := runEventWebhookHandler(wh.onVerification, w, body)
+	notification, ok := runEventWebhookHandler(wh.dispatch, wh.onVerification, w, body)
 	if !ok {
 		return
 	}
@@ -87,7 +104,9 @@ func (wh *Webhook) handleCallbackVerificationNotification(w http.ResponseWriter,
 
 // runEventWebhookHandler parses provided request body payload as JSON data to generic payload and runs handler in separate go-routine
 // with this payload if handler is defined and returns parsed payload with false, otherwise returns empty payload with true.
+// Webhook responds with 503 status code if handler can't be dispatched as it's shutting down, so Twitch retries later.
 func runEventWebhookHandler[Payload any](
+	dispatch func(func()) error,
 	handler func(Payload),
 	w http.ResponseWriter,
 	bodyPayload []byte,
@@ -100,7 +119,13 @@ func runEventWebhookHandler[Payload any](
 			return payload, false
 		}
 
-		go handler(payload)
+		err := dispatch(func() {
+			handler(payload)
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return payload, false
+		}
 	}
 
 	return payload, true
@@ -0,0 +1,29 @@
+package eventsub
+
+// WebhookOption is an optional setting for Webhook.
+type WebhookOption func(*Webhook)
+
+// WebhookWithDispatcher sets options of the dispatcher that runs event handlers, e.g. to run them by a bounded worker
+// pool instead of a goroutine per event.
+//
+// Default value is a dispatcher that runs each handler in a separate goroutine.
+func WebhookWithDispatcher(options ...DispatcherOption) WebhookOption {
+	return func(wh *Webhook) {
+		applyDispatcherOptions(&wh.dispatcher, options)
+	}
+}
+
+// WebhookWithSynchronousHandlers makes Webhook run event handlers synchronously before responding to the notification
+// request. Error returned from ContextHandler makes Webhook respond with non-2xx status code, so Twitch redelivers
+// notification. Handlers must respond fast enough, as Twitch considers notification failed if response takes more than
+// a few seconds.
+//
+// Notification that is not accepted is untracked, so its redelivery is not counted as duplicate. Custom EventTracker
+// must implement eventtracker.Untracker for that, otherwise redelivery is rejected as duplicate.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/handling-webhook-events/#processing-an-event.
+func WebhookWithSynchronousHandlers() WebhookOption {
+	return func(wh *Webhook) {
+		wh.synchronous = true
+	}
+}
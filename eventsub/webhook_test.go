@@ -0,0 +1,109 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/twirapp/twitchy/eventsub/eventtracker"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+func newTestWebhook(t *testing.T, es EventSub, options ...WebhookOption) *Webhook {
+	t.Helper()
+
+	wh, err := es.Webhook([]byte(testWebhookSecret), false, options...)
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	return wh
+}
+
+func newTestNotificationRequest(messageId string, eventType EventType, version, event string) *http.Request {
+	body := `{"subscription":{},"event":` + event + `}`
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Twitch-Eventsub-Message-Id", messageId)
+	r.Header.Set("Twitch-Eventsub-Message-Retry", "0")
+	r.Header.Set("Twitch-Eventsub-Message-Type", "notification")
+	r.Header.Set("Twitch-Eventsub-Message-Timestamp", time.Now().UTC().Format(time.RFC3339Nano))
+	r.Header.Set("Twitch-Eventsub-Subscription-Type", eventType.String())
+	r.Header.Set("Twitch-Eventsub-Subscription-Version", version)
+
+	return r
+}
+
+func TestWebhookRedeliveryAfterSynchronousHandlerError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	es := New(WithEventTracker(eventtracker.NewInMemoryEventTracker(ctx)))
+	wh := newTestWebhook(t, es, WebhookWithSynchronousHandlers())
+
+	var calls atomic.Int64
+
+	wh.OnChannelBanContext(func(context.Context, ChannelBanEvent, WebhookNotificationMetadata) error {
+		if calls.Add(1) == 1 {
+			return errors.New("db down")
+		}
+
+		return nil
+	})
+
+	deliver := func() int {
+		w := httptest.NewRecorder()
+		wh.ServeHTTP(w, newTestNotificationRequest("message-1", EventTypeChannelBan, "1", `{"user_id":"1"}`))
+
+		return w.Code
+	}
+
+	if status := deliver(); status != http.StatusInternalServerError {
+		t.Fatalf("expected failed delivery to respond with %d, got %d", http.StatusInternalServerError, status)
+	}
+
+	if status := deliver(); status != http.StatusOK {
+		t.Fatalf("expected redelivery to respond with %d, got %d", http.StatusOK, status)
+	}
+
+	if status := deliver(); status != http.StatusBadRequest {
+		t.Fatalf("expected duplicate of accepted delivery to respond with %d, got %d", http.StatusBadRequest, status)
+	}
+
+	if calls.Load() != 2 {
+		t.Fatalf("expected handler to run 2 times, got %d", calls.Load())
+	}
+}
+
+func TestWebhookRejectsNotificationWhileShuttingDown(t *testing.T) {
+	wh := newTestWebhook(t, New())
+
+	wh.OnChannelBan(func(ChannelBanEvent, WebhookNotificationMetadata) {
+		t.Error("handler must not run after shutdown")
+	})
+
+	if err := wh.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	metadata := WebhookNotificationMetadata{
+		MessageId:           "1",
+		MessageType:         "notification",
+		SubscriptionType:    EventTypeChannelBan,
+		SubscriptionVersion: "1",
+	}
+
+	// Notification accepted right before shutdown is dispatched to the closed dispatcher.
+	wh.handleEventNotification(context.Background(), w, metadata, []byte(`{"subscription":{},"event":{}}`))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -17,8 +18,16 @@ import (
 )
 
 var (
-	ErrConnectionUnused = errors.New("connection is unused as subscription time limit exceeded")
-	ErrReconnectTimeout = errors.New("reconnect grace time expired")
+	ErrInternalServerError      = errors.New("internal server error")
+	ErrClientSentInboundTraffic = errors.New("client sent inbound traffic")
+	ErrClientFailedPingPong     = errors.New("client failed ping-pong")
+	ErrConnectionUnused         = errors.New("connection is unused as subscription time limit exceeded")
+	ErrReconnectTimeout         = errors.New("reconnect grace time expired")
+	ErrNetworkTimeout           = errors.New("network timeout")
+	ErrNetworkError             = errors.New("network error")
+	ErrInvalidReconnect         = errors.New("invalid reconnect")
+	ErrWelcomeTimeout           = errors.New("welcome message is not received in time")
+	ErrKeepaliveTimeout         = errors.New("no messages received during keepalive timeout")
 )
 
 const websocketURL = "wss://eventsub.wss.twitch.tv/ws"
@@ -28,48 +37,71 @@ type Websocket struct {
 	client       *http.Client
 	eventTracker eventtracker.EventTracker
 
-	serverURL          string
-	serverReconnectURL string
+	serverURL string
 
 	retryAttempts uint
 	retryDelay    time.Duration
+	backoff       BackoffPolicy
+	supervised    bool
 
 	conn            *websocket.Conn
 	connDialOptions *websocket.DialOptions
 
 	keepaliveSeconds uint
-	lastKeepalive    atomic.Value
-
-	isActive       atomic.Bool
-	isReconnecting atomic.Bool
+	keepaliveTimeout atomic.Int64
+	lastMessage      atomic.Int64
+	welcomeTimeout   time.Duration
+
+	// stateMu guards state, conn, disconnect related fields and state change callback.
+	stateMu        sync.Mutex
+	state          WebsocketState
+	disconnecting  bool
+	abortCause     error
+	stopSupervisor context.CancelFunc
+	onStateChange  func(from, to WebsocketState, cause error)
+	// lastStateChange is closed when state change callback of the last transition returns, so callbacks of the next
+	// transitions wait for it.
+	lastStateChange chan struct{}
+
+	disconnectRequested atomic.Bool
+	welcomes            atomic.Uint64
 
 	restart     chan struct{}
 	welcome     chan struct{}
 	reconnected chan struct{}
 	disconnect  chan struct{}
 
-	onWelcome        func(WebsocketWelcomeMessage)
-	onKeepalive      func(WebsocketKeepaliveMessage)
-	onPing           func()
-	onReconnect      func(WebsocketReconnectMessage)
-	onReconnectError func(error)
-	onDisconnect     func()
+	subscriber      Subscriber
+	sessionId       string
+	sessionReady    chan struct{}
+	subscriptions   []websocketSubscriptionEntry
+	subscriptionsMu sync.Mutex
+
+	onWelcome          func(WebsocketWelcomeMessage)
+	onKeepalive        func(WebsocketKeepaliveMessage)
+	onPing             func()
+	onReconnect        func(WebsocketReconnectMessage)
+	onReconnectError   func(error)
+	onDisconnect       func()
+	onRevocation       func(WebsocketRevocationMessage[AnyCondition])
+	onResubscribeError func(WebsocketSubscription, error)
 
 	callback[WebsocketNotificationMetadata]
 }
 
 func newWebsocket(eventTracker eventtracker.EventTracker, options ...WebsocketOption) *Websocket {
 	ws := &Websocket{
-		client:             http.DefaultClient,
-		eventTracker:       eventTracker,
-		serverURL:          websocketURL,
-		serverReconnectURL: websocketURL,
-		retryAttempts:      5,
-		retryDelay:         1 * time.Second,
-		keepaliveSeconds:   600,
-		reconnected:        make(chan struct{}),
-		welcome:            make(chan struct{}),
-		restart:            make(chan struct{}),
+		client:           http.DefaultClient,
+		eventTracker:     eventTracker,
+		serverURL:        websocketURL,
+		retryAttempts:    5,
+		retryDelay:       1 * time.Second,
+		keepaliveSeconds: 600,
+		welcomeTimeout:   10 * time.Second,
+		reconnected:      make(chan struct{}),
+		welcome:          make(chan struct{}, 1),
+		restart:          make(chan struct{}),
+		sessionReady:     make(chan struct{}),
 	}
 
 	for _, option := range options {
@@ -79,6 +111,8 @@ func newWebsocket(eventTracker eventtracker.EventTracker, options ...WebsocketOp
 	ws.connDialOptions = &websocket.DialOptions{
 		HTTPClient: ws.client,
 		OnPingReceived: func(_ context.Context, _ []byte) bool {
+			ws.touch()
+
 			if ws.onPing != nil {
 				go ws.onPing()
 			}
@@ -90,27 +124,161 @@ func newWebsocket(eventTracker eventtracker.EventTracker, options ...WebsocketOp
 	return ws
 }
 
+// Connect connects to the eventsub server and blocks on processing messages until client is disconnected.
+//
+// In supervised mode (see WebsocketWithSupervision) Connect connects again according to the BackoffPolicy each time
+// connection is lost, and blocks until the context is canceled, Disconnect is called or error is not retryable.
 func (ws *Websocket) Connect(ctx context.Context) error {
-	if !ws.setActive() {
-		return nil
+	if ws.supervised {
+		return ws.supervise(ctx)
+	}
+
+	return ws.run(ctx)
+}
+
+// supervise blocks on running client and running it again with backoff each time connection is lost.
+func (ws *Websocket) supervise(parentCtx context.Context) error {
+	ctx, cancel := context.WithCancel(parentCtx)
+	defer cancel()
+
+	ws.stateMu.Lock()
+	ws.disconnectRequested.Store(false)
+	ws.stopSupervisor = cancel
+	ws.stateMu.Unlock()
+
+	defer func() {
+		ws.stateMu.Lock()
+		ws.stopSupervisor = nil
+		ws.stateMu.Unlock()
+	}()
+
+	stopDisconnect := context.AfterFunc(ctx, func() {
+		_ = ws.Disconnect()
+	})
+	defer stopDisconnect()
+
+	// stopped returns error Connect should return with if supervisor is stopped.
+	stopped := func() (error, bool) {
+		if parentCtx.Err() != nil {
+			return parentCtx.Err(), true
+		}
+
+		if ws.disconnectRequested.Load() {
+			return nil, true
+		}
+
+		return nil, false
+	}
+
+	policy := ws.backoff
+	if policy == nil {
+		policy = DefaultExponentialBackoff()
+	}
+
+	var failedAttempts uint
+
+	for {
+		welcomes := ws.welcomes.Load()
+
+		err := ws.run(ctx)
+		if stopErr, ok := stopped(); ok {
+			return stopErr
+		}
+
+		// Session was established, so backoff starts over.
+		if ws.welcomes.Load() != welcomes {
+			failedAttempts = 0
+		}
+
+		if err != nil && !policy.Retryable(err) {
+			return err
+		}
+
+		failedAttempts++
+
+		if attempts := policy.Attempts(); attempts > 0 && failedAttempts >= attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			stopErr, _ := stopped()
+			return stopErr
+		case <-time.After(policy.Delay(failedAttempts, err)):
+		}
 	}
+}
 
-	// We should recreate disconnect channel on each connect so user can do connect-disconnect cycle as many times as he
-	// wants and don't get panic on double-close already closed channel.
-	ws.disconnect = make(chan struct{})
+// run connects to the eventsub server and blocks on processing messages until client is disconnected.
+func (ws *Websocket) run(ctx context.Context) (err error) {
+	var disconnect chan struct{}
+
+	ok := ws.transition(WebsocketStateDialing, nil, func() bool {
+		// We should recreate disconnect channel on each connect so user can do connect-disconnect cycle as many times
+		// as he wants and don't get panic on double-close already closed channel.
+		ws.disconnect = make(chan struct{})
+		ws.disconnecting = false
+		ws.abortCause = nil
+		ws.conn = nil
+
+		disconnect = ws.disconnect
+		return true
+	})
+	if !ok {
+		return nil
+	}
 
 	defer func() {
-		ws.setInactivate()
+		ws.resetSession()
+		ws.transition(WebsocketStateClosed, err, nil)
 
 		if ws.onDisconnect != nil {
 			go ws.onDisconnect()
 		}
 	}()
 
-	if err := ws.connect(ctx, ws.serverURL); err != nil {
+	// Supervisor retries the whole run, so there is no need to retry connection here.
+	connect := ws.connect
+	if ws.supervised {
+		connect = ws.connectWithoutRetry
+	}
+
+	// Dial is canceled if client is disconnected before connection is established.
+	dialCtx, cancelDial := context.WithCancel(ctx)
+	go func(disconnect <-chan struct{}) {
+		select {
+		case <-disconnect:
+			cancelDial()
+		case <-dialCtx.Done():
+		}
+	}(disconnect)
+
+	conn, err := connect(dialCtx, ws.serverURL)
+	cancelDial()
+
+	if err != nil {
+		if isClosed(disconnect) {
+			return nil
+		}
+
 		return err
 	}
 
+	ok = ws.transition(WebsocketStateAwaitingWelcome, nil, func() bool {
+		if ws.disconnecting {
+			return false
+		}
+
+		ws.conn = conn
+		return true
+	})
+	if !ok {
+		_ = conn.Close(websocket.StatusNormalClosure, "client is shutting down")
+		return nil
+	}
+
+	ws.touch()
+
 	// Context of the whole instance including side workers (e.g. keepalive worker).
 	lifecycleCtx, stopLifecycle := context.WithCancel(context.Background())
 	defer stopLifecycle()
@@ -118,70 +286,138 @@ func (ws *Websocket) Connect(ctx context.Context) error {
 	// Context of the connection itself that can be canceled to restart for example.
 	connectionCtx, stopConnection := context.WithCancel(lifecycleCtx)
 
-	stop := make(chan error)
-
 	go ws.startKeepaliveWorker(lifecycleCtx)
-	go func() {
-		stop <- ws.startReadWorker(connectionCtx)
-	}()
+	stop := ws.goReadWorker(connectionCtx, conn)
+
+	welcomeTimeout, stopWelcomeTimeout := newTimeout(ws.welcomeTimeout)
+	defer stopWelcomeTimeout()
 
 	for {
 		select {
-		case err := <-stop:
-			if ws.isReconnecting.Load() {
-				continue
-			}
+		case err = <-stop:
 			stopConnection()
+
+			if cause := ws.getAbortCause(); cause != nil {
+				return cause
+			}
+
 			return err
+		case <-welcomeTimeout:
+			if ws.State() == WebsocketStateAwaitingWelcome {
+				ws.abort(ErrWelcomeTimeout)
+			}
 		case <-ws.restart:
 			stopConnection()
 
-			// Restore connection context to reuse it on next restart (reconnect).
+			// Restore connection context to reuse it on next restart (reconnect). Stop channel is replaced too, so result
+			// of the worker reading from old connection is ignored.
 			connectionCtx, stopConnection = context.WithCancel(lifecycleCtx)
-
-			go func() {
-				stop <- ws.startReadWorker(connectionCtx)
-			}()
-		case <-ws.disconnect:
+			stop = ws.goReadWorker(connectionCtx, ws.connection())
+		case <-disconnect:
+			// Disconnect channel is closed, so we should stop receiving from it.
+			disconnect = nil
 			stopLifecycle()
 		}
 	}
 }
 
+// Disconnect disconnects client from the eventsub server. It's safe to call it at any state of the connection,
+// including while client is still connecting.
 func (ws *Websocket) Disconnect() error {
-	if !ws.setInactivate() {
+	ws.stateMu.Lock()
+
+	if ws.stopSupervisor != nil {
+		ws.disconnectRequested.Store(true)
+		ws.stopSupervisor()
+		ws.stopSupervisor = nil
+	}
+
+	if ws.disconnecting || !ws.state.IsActive() {
+		ws.stateMu.Unlock()
 		return nil
 	}
 
-	ws.disconnect <- struct{}{}
+	ws.disconnecting = true
+	ws.disconnectRequested.Store(true)
 	close(ws.disconnect)
 
-	if err := ws.conn.Close(websocket.StatusNormalClosure, "client is shutting down"); err != nil {
+	conn := ws.conn
+	ws.stateMu.Unlock()
+
+	// Client is still connecting, so dial is just canceled.
+	if conn == nil {
+		return nil
+	}
+
+	if err := conn.Close(websocket.StatusNormalClosure, "client is shutting down"); err != nil {
 		return fmt.Errorf("close connection: %w", err)
 	}
 
 	return nil
 }
 
-func (ws *Websocket) connect(ctx context.Context, serverURL string) error {
-	if ws.retryAttempts > 0 {
-		return retry.Do(
-			func() error {
-				return ws.connectWithoutRetry(ctx, serverURL)
-			},
-			retry.Attempts(ws.retryAttempts),
-			retry.Delay(ws.retryDelay),
-			retry.Context(ctx),
-		)
+// Shutdown gracefully shuts down the client: it disconnects from the eventsub server, so no new messages are accepted,
+// and waits for in-flight event handlers to finish until the context is done. ShutdownError with a number of abandoned
+// handlers is returned if the context is done first.
+//
+// Client can't be used after shutdown, as events are not dispatched to handlers anymore.
+func (ws *Websocket) Shutdown(ctx context.Context) error {
+	disconnectErr := ws.Disconnect()
+
+	if err := ws.drain(ctx); err != nil {
+		return err
 	}
 
-	return ws.connectWithoutRetry(ctx, serverURL)
+	if disconnectErr != nil {
+		return fmt.Errorf("disconnect: %w", disconnectErr)
+	}
+
+	return nil
 }
 
-func (ws *Websocket) connectWithoutRetry(ctx context.Context, serverURL string) error {
+func (ws *Websocket) connect(ctx context.Context, serverURL string) (*websocket.Conn, error) {
+	policy := ws.backoffPolicy()
+	if policy == nil {
+		return ws.connectWithoutRetry(ctx, serverURL)
+	}
+
+	var failedAttempts uint
+
+	return retry.DoWithData(
+		func() (*websocket.Conn, error) {
+			return ws.connectWithoutRetry(ctx, serverURL)
+		},
+		retry.Attempts(policy.Attempts()),
+		retry.DelayType(func(_ uint, err error, _ *retry.Config) time.Duration {
+			failedAttempts++
+			return policy.Delay(failedAttempts, err)
+		}),
+		retry.RetryIf(policy.Retryable),
+		retry.Context(ctx),
+	)
+}
+
+// backoffPolicy returns BackoffPolicy set by user or composed of retry attempts and delay, or nil if retries are
+// disabled.
+func (ws *Websocket) backoffPolicy() BackoffPolicy {
+	if ws.backoff != nil {
+		return ws.backoff
+	}
+
+	if ws.retryAttempts == 0 {
+		return nil
+	}
+
+	return ConstantBackoff{
+		MaxAttempts: ws.retryAttempts,
+		Interval:    ws.retryDelay,
+	}
+}
+
+func (ws *Websocket) connectWithoutRetry(ctx context.Context, serverURL string) (*websocket.Conn, error) {
 	rawURL, err := url.Parse(serverURL)
 	if err != nil {
-		return fmt.Errorf("parse server url: %w", err)
+		return nil, fmt.Errorf("parse server url: %w", err)
 	}
 
 	keepaliveTimeoutSeconds := strconv.FormatUint(uint64(ws.keepaliveSeconds), 10)
@@ -193,23 +429,40 @@ func (ws *Websocket) connectWithoutRetry(ctx context.Context, serverURL string)
 
 	conn, _, err := websocket.Dial(ctx, rawURL.String(), ws.connDialOptions)
 	if err != nil {
-		return fmt.Errorf("connect to server: %w", err)
+		return nil, fmt.Errorf("connect to server: %w", err)
 	}
 
-	ws.conn = conn
-	return nil
+	return conn, nil
 }
 
-// reconnect reconnects to the new eventsub edge server according to the reconnect message flow.
+// reconnect reconnects to the new eventsub edge server according to the reconnect message flow, or to the server URL
+// if session is dead. Cause is reported as a cause of transition to the reconnecting state.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#reconnect-message.
-func (ws *Websocket) reconnect(ctx context.Context, reconnectURL string) error {
-	if !ws.setReconnecting() {
+func (ws *Websocket) reconnect(ctx context.Context, reconnectURL string, cause error) (err error) {
+	var (
+		oldConnection *websocket.Conn
+		restarted     bool
+	)
+
+	ok := ws.transition(WebsocketStateReconnecting, cause, func() bool {
+		if ws.disconnecting {
+			return false
+		}
+
+		// Save old connection to close it later after connecting to the new edge server.
+		oldConnection = ws.conn
+		return true
+	})
+	if !ok {
 		return nil
 	}
 
 	defer func() {
-		ws.isReconnecting.Store(false)
+		// Client is still connected to the old edge server if reconnect failed before read worker was restarted.
+		if err != nil && !restarted {
+			ws.transition(WebsocketStateConnected, err, nil)
+		}
 
 		select {
 		case ws.reconnected <- struct{}{}:
@@ -217,47 +470,94 @@ func (ws *Websocket) reconnect(ctx context.Context, reconnectURL string) error {
 		}
 	}()
 
-	// Save old connection to close it later after connecting to the new edge server and set new reconnection URL.
-	oldConnection := ws.conn
-	ws.serverReconnectURL = reconnectURL
-
-	if err := ws.connect(ctx, reconnectURL); err != nil {
+	conn, err := ws.connect(ctx, reconnectURL)
+	if err != nil {
 		return fmt.Errorf("connect: %w", err)
 	}
 
-	// Signal read worker to stop reading from old connection, restart with new one and wait for welcome message.
-	ws.restart <- struct{}{}
-	<-ws.welcome
+	ws.stateMu.Lock()
+	ws.conn = conn
+	ws.stateMu.Unlock()
 
-	// Close old connection to complete reconnect flow.
-	if err := oldConnection.Close(websocket.StatusNormalClosure, "connected to the new edge server"); err != nil {
-		return fmt.Errorf("close old connection: %w", err)
+	// Drop welcome signal of the previous connection, so we wait for welcome message of the new one.
+	select {
+	case <-ws.welcome:
+	default:
 	}
 
+	// Signal read worker to stop reading from old connection, restart with new one and wait for welcome message that
+	// moves client to the connected state.
+	select {
+	case ws.restart <- struct{}{}:
+		restarted = true
+	case <-ctx.Done():
+		_ = conn.Close(websocket.StatusNormalClosure, "client is shutting down")
+		return ctx.Err()
+	}
+
+	welcomeTimeout, stopWelcomeTimeout := newTimeout(ws.welcomeTimeout)
+	defer stopWelcomeTimeout()
+
+	select {
+	case <-ws.welcome:
+	case <-welcomeTimeout:
+		// Old connection is not read anymore, so the whole client is stopped.
+		ws.abort(ErrWelcomeTimeout)
+		_ = oldConnection.CloseNow()
+
+		return ErrWelcomeTimeout
+	}
+
+	// Close old connection in background to complete reconnect flow, as it may be dead and close handshake would
+	// block until timeout.
+	go func() {
+		err := oldConnection.Close(websocket.StatusNormalClosure, "connected to the new edge server")
+		if err != nil && ws.onReconnectError != nil {
+			ws.onReconnectError(fmt.Errorf("close old connection: %w", err))
+		}
+	}()
+
 	return nil
 }
 
+// goReadWorker starts read worker in a separate goroutine and returns channel its result is sent to.
+func (ws *Websocket) goReadWorker(ctx context.Context, conn *websocket.Conn) <-chan error {
+	stop := make(chan error, 1)
+
+	go func() {
+		stop <- ws.startReadWorker(ctx, conn)
+	}()
+
+	return stop
+}
+
 // startReadWorker starts and blocks on reading and processing messages from the connection.
-func (ws *Websocket) startReadWorker(ctx context.Context) error {
+func (ws *Websocket) startReadWorker(ctx context.Context, conn *websocket.Conn) error {
 	for {
-		_, data, err := ws.conn.Read(ctx)
+		_, data, err := conn.Read(ctx)
+		if err == nil {
+			ws.touch()
+		}
+
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				return nil
 			}
 
-			switch websocket.CloseStatus(err) {
-			case websocket.StatusNormalClosure:
-				if ws.isReconnecting.Load() {
-					<-ws.reconnected
-					continue
+			if websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+				// Old connection is closed when reconnect is completed, so read worker will be restarted.
+				if ws.State() == WebsocketStateReconnecting {
+					select {
+					case <-ws.reconnected:
+					case <-ctx.Done():
+					}
 				}
 
 				return nil
-			case websocket.StatusCode(4003):
-				return ErrConnectionUnused
-			case websocket.StatusCode(4004):
-				return ErrReconnectTimeout
+			}
+
+			if closeErr, ok := newCloseError(err); ok {
+				return closeErr
 			}
 
 			return fmt.Errorf("read from connection: %w", err)
@@ -279,7 +579,7 @@ func (ws *Websocket) startReadWorker(ctx context.Context) error {
 
 		err, safe := isSafeMessage(
 			ctx,
-			ws.onDuplicate,
+			ws.getOnDuplicate(),
 			ws.eventTracker,
 			metadata,
 			metadata.MessageId,
@@ -299,56 +599,117 @@ func (ws *Websocket) startReadWorker(ctx context.Context) error {
 	}
 }
 
-// startKeepaliveWorker starts and blocks on trying to keep connection healthy and reconnect if needed.
+// startKeepaliveWorker starts and blocks on watching connection liveness. Any received message (not only keepalive
+// one) indicates that connection is healthy, and if no messages were received during keepalive timeout, session is
+// considered dead and client connects to the eventsub server again to get a new one.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#keepalive-message.
 func (ws *Websocket) startKeepaliveWorker(ctx context.Context) {
-	const delay = 1 * time.Second
+	const (
+		interval = 1 * time.Second
+		// grace is added to keepalive timeout to tolerate network latency.
+		grace = 1 * time.Second
+	)
 
-	keepalive := time.NewTicker(time.Second*time.Duration(ws.keepaliveSeconds) + delay)
-	defer keepalive.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-keepalive.C:
-			var (
-				now, _               = timestampUTCFromString(time.Now().String())
-				lastKeepaliveSeconds = now.Sub(ws.getLastKeepalive()).Seconds()
-			)
+		case <-ticker.C:
+			if ws.State() != WebsocketStateConnected {
+				continue
+			}
 
-			if lastKeepaliveSeconds < float64(ws.keepaliveSeconds) {
+			if time.Since(ws.getLastMessage()) < ws.getKeepaliveTimeout()+grace {
 				continue
 			}
 
-			// We must reconnect to the eventsub server if keepalive timeout expired.
-			if err := ws.reconnect(ctx, ws.serverReconnectURL); err != nil {
-				if ws.onReconnectError == nil {
-					continue
+			// Reconnect URL is only valid during reconnect message flow and dead session can't be resumed, so we
+			// must connect to the server URL to get a new session.
+			if err := ws.reconnect(ctx, ws.serverURL, ErrKeepaliveTimeout); err != nil {
+				if ws.onReconnectError != nil {
+					go ws.onReconnectError(err)
 				}
 
-				go ws.onReconnectError(err)
+				ws.abort(fmt.Errorf("%w: %w", ErrKeepaliveTimeout, err))
 			}
 		}
 	}
 }
 
-func (ws *Websocket) setReconnecting() bool {
-	return ws.isReconnecting.CompareAndSwap(false, true)
+// abort closes current connection, so Connect returns with the cause instead of error of reading from the connection.
+func (ws *Websocket) abort(cause error) {
+	ws.stateMu.Lock()
+
+	if ws.abortCause == nil {
+		ws.abortCause = cause
+	}
+
+	conn := ws.conn
+	ws.stateMu.Unlock()
+
+	if conn != nil {
+		_ = conn.CloseNow()
+	}
+}
+
+func (ws *Websocket) getAbortCause() error {
+	ws.stateMu.Lock()
+	defer ws.stateMu.Unlock()
+
+	return ws.abortCause
+}
+
+// touch marks connection as alive at the moment.
+func (ws *Websocket) touch() {
+	ws.lastMessage.Store(time.Now().UnixNano())
+}
+
+func (ws *Websocket) getLastMessage() time.Time {
+	return time.Unix(0, ws.lastMessage.Load())
 }
 
-func (ws *Websocket) setActive() bool {
-	return ws.isActive.CompareAndSwap(false, true)
+func (ws *Websocket) setKeepaliveTimeout(seconds int) {
+	if seconds <= 0 {
+		return
+	}
+
+	ws.keepaliveTimeout.Store(int64(time.Duration(seconds) * time.Second))
 }
 
-func (ws *Websocket) setInactivate() bool {
-	return ws.isActive.CompareAndSwap(true, false)
+// getKeepaliveTimeout returns keepalive timeout of the session, or the requested one if welcome message was not
+// received yet.
+func (ws *Websocket) getKeepaliveTimeout() time.Duration {
+	if timeout := ws.keepaliveTimeout.Load(); timeout > 0 {
+		return time.Duration(timeout)
+	}
+
+	return time.Duration(ws.keepaliveSeconds) * time.Second
 }
 
-func (ws *Websocket) setKeepalive(timestamp TimestampUTC) {
-	ws.lastKeepalive.Store(timestamp)
+// newTimeout returns channel that receives after the timeout and function to release its resources. Channel never
+// receives if timeout is zero.
+func newTimeout(timeout time.Duration) (<-chan time.Time, func()) {
+	if timeout <= 0 {
+		return nil, func() {}
+	}
+
+	timer := time.NewTimer(timeout)
+
+	return timer.C, func() {
+		timer.Stop()
+	}
 }
 
-func (ws *Websocket) getLastKeepalive() time.Time {
-	lastKeepalive := ws.lastKeepalive.Load().(TimestampUTC)
-	return lastKeepalive.Time
+// isClosed returns true if the channel is closed.
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
 }
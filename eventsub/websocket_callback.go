@@ -38,3 +38,16 @@ func (ws *Websocket) OnReconnectError(onReconnectError func(error)) {
 func (ws *Websocket) OnDisconnect(onDisconnect func()) {
 	ws.onDisconnect = onDisconnect
 }
+
+// OnRevocation invokes when eventsub sends revocation message which indicates that Twitch revoked the subscription.
+// Revoked subscription is also forgotten by Websocket, so it will not be recreated on a new session.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#revocation-message.
+func (ws *Websocket) OnRevocation(onRevocation func(WebsocketRevocationMessage[AnyCondition])) {
+	ws.onRevocation = onRevocation
+}
+
+// OnResubscribeError invokes when subscription failed to be recreated for a new session.
+func (ws *Websocket) OnResubscribeError(onResubscribeError func(WebsocketSubscription, error)) {
+	ws.onResubscribeError = onResubscribeError
+}
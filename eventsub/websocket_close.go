@@ -0,0 +1,129 @@
+package eventsub
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coder/websocket"
+)
+
+// CloseCode is a code the eventsub websocket server closes connection with.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#close-message.
+type CloseCode int
+
+const (
+	CloseInternalServerError       CloseCode = 4000
+	CloseClientSentInboundTraffic  CloseCode = 4001
+	CloseClientFailedPingPong      CloseCode = 4002
+	CloseConnectionUnused          CloseCode = 4003
+	CloseReconnectGraceTimeExpired CloseCode = 4004
+	CloseNetworkTimeout            CloseCode = 4005
+	CloseNetworkError              CloseCode = 4006
+	CloseInvalidReconnect          CloseCode = 4007
+)
+
+// CloseClass is a class of close codes that defines how client should retry connection.
+type CloseClass int
+
+const (
+	// CloseClassTransient is a class of server or network failures that should be retried with backoff.
+	CloseClassTransient CloseClass = iota
+	// CloseClassClient is a class of failures caused by client that should be retried with backoff.
+	CloseClassClient
+	// CloseClassReconnect is a class of failed reconnects that should be retried immediately using the default
+	// server URL instead of reconnect one.
+	CloseClassReconnect
+	// CloseClassFatal is a class of failures that should not be retried as new connection will fail for the same
+	// reason.
+	CloseClassFatal
+)
+
+// Err returns sentinel error of the code, or nil if the code is unknown.
+func (cc CloseCode) Err() error {
+	switch cc {
+	case CloseInternalServerError:
+		return ErrInternalServerError
+	case CloseClientSentInboundTraffic:
+		return ErrClientSentInboundTraffic
+	case CloseClientFailedPingPong:
+		return ErrClientFailedPingPong
+	case CloseConnectionUnused:
+		return ErrConnectionUnused
+	case CloseReconnectGraceTimeExpired:
+		return ErrReconnectTimeout
+	case CloseNetworkTimeout:
+		return ErrNetworkTimeout
+	case CloseNetworkError:
+		return ErrNetworkError
+	case CloseInvalidReconnect:
+		return ErrInvalidReconnect
+	default:
+		return nil
+	}
+}
+
+// Class returns class of the code. Unknown codes are considered transient.
+func (cc CloseCode) Class() CloseClass {
+	switch cc {
+	case CloseClientSentInboundTraffic, CloseClientFailedPingPong:
+		return CloseClassClient
+	case CloseReconnectGraceTimeExpired, CloseInvalidReconnect:
+		return CloseClassReconnect
+	case CloseConnectionUnused:
+		return CloseClassFatal
+	default:
+		return CloseClassTransient
+	}
+}
+
+// CloseError is an error of connection closed by the eventsub websocket server. It wraps sentinel error of the code,
+// so it can be checked with errors.Is (e.g. errors.Is(err, ErrConnectionUnused)).
+type CloseError struct {
+	Code   CloseCode
+	Reason string
+}
+
+var _ error = (*CloseError)(nil)
+
+func (ce *CloseError) Error() string {
+	if ce.Reason == "" {
+		return fmt.Sprintf("connection closed with code %d", ce.Code)
+	}
+
+	return fmt.Sprintf("connection closed with code %d: %s", ce.Code, ce.Reason)
+}
+
+func (ce *CloseError) Unwrap() error {
+	return ce.Code.Err()
+}
+
+// CloseClassOf returns close class of the error and true if the error is CloseError.
+func CloseClassOf(err error) (CloseClass, bool) {
+	var closeErr *CloseError
+
+	if !errors.As(err, &closeErr) {
+		return 0, false
+	}
+
+	return closeErr.Code.Class(), true
+}
+
+// newCloseError converts error of the connection closed with eventsub specific code to CloseError.
+func newCloseError(err error) (*CloseError, bool) {
+	var wsCloseErr websocket.CloseError
+
+	if !errors.As(err, &wsCloseErr) {
+		return nil, false
+	}
+
+	// Eventsub specific codes are in the private use range.
+	if wsCloseErr.Code < 4000 || wsCloseErr.Code > 4999 {
+		return nil, false
+	}
+
+	return &CloseError{
+		Code:   CloseCode(wsCloseErr.Code),
+		Reason: wsCloseErr.Reason,
+	}, true
+}
@@ -39,7 +39,7 @@ func (ws *Websocket) handleMessage(ctx context.Context, message websocketRawMess
 
 	switch metadata.MessageType {
 	case "session_welcome":
-		if err := ws.handleWelcomeMessage(metadata, payload); err != nil {
+		if err := ws.handleWelcomeMessage(ctx, metadata, payload); err != nil {
 			return fmt.Errorf("handle welcome message: %w", err)
 		}
 	case "session_keepalive":
@@ -49,7 +49,7 @@ func (ws *Websocket) handleMessage(ctx context.Context, message websocketRawMess
 			return fmt.Errorf("handle reconnect message: %w", err)
 		}
 	case "notification":
-		if err := ws.handleNotificationMessage(metadata, payload); err != nil {
+		if err := ws.handleNotificationMessage(ctx, metadata, payload); err != nil {
 			return fmt.Errorf("handle notification message: %w", err)
 		}
 	case "revocation":
@@ -65,14 +65,49 @@ func (ws *Websocket) handleMessage(ctx context.Context, message websocketRawMess
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#revocation-message.
 func (ws *Websocket) handleRevocationMessage(rawMetadata websocketRawMessageMetadata, rawPayload json.RawMessage) error {
-	// TODO: implement me
+	var revocationPayload WebsocketRevocationPayload[AnyCondition]
+
+	if err := json.Unmarshal(rawPayload, &revocationPayload); err != nil {
+		return fmt.Errorf("unmarshal raw payload: %w", err)
+	}
+
+	revocationMessage := WebsocketRevocationMessage[AnyCondition]{
+		WebsocketMessage: WebsocketMessage[WebsocketRevocationMetadata, WebsocketRevocationPayload[AnyCondition]]{
+			Metadata: WebsocketRevocationMetadata{
+				MessageId:           rawMetadata.MessageId,
+				MessageType:         rawMetadata.MessageType,
+				MessageTimestamp:    rawMetadata.MessageTimestamp,
+				SubscriptionType:    rawMetadata.SubscriptionType.String(),
+				SubscriptionVersion: rawMetadata.SubscriptionVersion,
+			},
+			Payload: revocationPayload,
+		},
+	}
+
+	subscription := revocationPayload.Subscription
+
+	key, err := websocketSubscriptionKey(EventType(subscription.Type), subscription.Version, subscription.Condition)
+	if err != nil {
+		return fmt.Errorf("subscription key: %w", err)
+	}
+
+	ws.forgetSubscription(key)
+
+	if ws.onRevocation != nil {
+		go ws.onRevocation(revocationMessage)
+	}
+
 	return nil
 }
 
 // handleWelcomeMessage handles welcome message that is sent when you connect to the server.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#welcome-message.
-func (ws *Websocket) handleWelcomeMessage(rawMetadata websocketRawMessageMetadata, rawPayload json.RawMessage) error {
+func (ws *Websocket) handleWelcomeMessage(
+	ctx context.Context,
+	rawMetadata websocketRawMessageMetadata,
+	rawPayload json.RawMessage,
+) error {
 	var welcomePayload WebsocketWelcomePayload
 
 	if err := json.Unmarshal(rawPayload, &welcomePayload); err != nil {
@@ -88,6 +123,16 @@ func (ws *Websocket) handleWelcomeMessage(rawMetadata websocketRawMessageMetadat
 		Payload: welcomePayload,
 	}
 
+	ws.welcomes.Add(1)
+
+	// Welcome message either completes connection or reconnect flow.
+	if !ws.transition(WebsocketStateConnected, nil, nil) {
+		return nil
+	}
+
+	ws.setKeepaliveTimeout(welcomeMessage.Payload.Session.KeepaliveTimeoutSeconds)
+	ws.setSession(ctx, welcomeMessage.Payload.Session.Id)
+
 	select {
 	case ws.welcome <- struct{}{}:
 	default:
@@ -113,8 +158,6 @@ func (ws *Websocket) handleKeepaliveMessage(rawMetadata websocketRawMessageMetad
 		Payload: struct{}{},
 	}
 
-	ws.setKeepalive(keepaliveMessage.Metadata.MessageTimestamp)
-
 	if ws.onKeepalive != nil {
 		go ws.onKeepalive(keepaliveMessage)
 	}
@@ -144,7 +187,7 @@ func (ws *Websocket) handleReconnectMessage(
 	}
 
 	go func() {
-		err := ws.reconnect(ctx, reconnectMessage.Payload.Session.ReconnectURL)
+		err := ws.reconnect(ctx, reconnectMessage.Payload.Session.ReconnectURL, nil)
 		if err == nil {
 			return
 		}
@@ -164,7 +207,11 @@ func (ws *Websocket) handleReconnectMessage(
 // handleNotificationMessage handles notification message that is sent when an event occurs.
 //
 // Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#notification-message.
-func (ws *Websocket) handleNotificationMessage(rawMetadata websocketRawMessageMetadata, rawPayload json.RawMessage) error {
+func (ws *Websocket) handleNotificationMessage(
+	ctx context.Context,
+	rawMetadata websocketRawMessageMetadata,
+	rawPayload json.RawMessage,
+) error {
 	var wsRawEvent websocketRawEvent
 
 	if err := json.Unmarshal(rawPayload, &wsRawEvent); err != nil {
@@ -184,7 +231,7 @@ func (ws *Websocket) handleNotificationMessage(rawMetadata websocketRawMessageMe
 		Event:        wsRawEvent.Event,
 	}
 
-	if err := ws.callback.runEventCallback(metadata.SubscriptionType, metadata.SubscriptionVersion, rawEvent, metadata); err != nil {
+	if err := ws.callback.runEventCallback(ctx, metadata.SubscriptionType, metadata.SubscriptionVersion, rawEvent, metadata); err != nil {
 		return fmt.Errorf("run event callback: %w", err)
 	}
 
@@ -65,3 +65,53 @@ func WebsocketWithRetryDelay(delay time.Duration) WebsocketOption {
 		ws.retryDelay = delay
 	}
 }
+
+// WebsocketWithSubscriber sets Subscriber that will be used to create subscriptions with Websocket.Subscribe and to
+// recreate them automatically when Websocket is connected to a new session.
+//
+// If this option is not set, Websocket.Subscribe returns ErrNoSubscriber as there is no default value.
+func WebsocketWithSubscriber(subscriber Subscriber) WebsocketOption {
+	return func(ws *Websocket) {
+		ws.subscriber = subscriber
+	}
+}
+
+// WebsocketWithBackoff sets BackoffPolicy of retries when connecting to the eventsub server fails (e.g. exponential
+// backoff with jitter). It overrides WebsocketWithRetryAttempts, WebsocketWithRetryDelay and WebsocketWithNoRetry.
+//
+// Default value is ConstantBackoff with 5 attempts and 1 second interval, or DefaultExponentialBackoff in supervised
+// mode.
+func WebsocketWithBackoff(policy BackoffPolicy) WebsocketOption {
+	return func(ws *Websocket) {
+		ws.backoff = policy
+	}
+}
+
+// WebsocketWithSupervision enables supervised mode where Connect keeps client connected until the context is canceled
+// or Disconnect is called. Each time connection is lost, client connects again according to the BackoffPolicy unless
+// error is not retryable.
+func WebsocketWithSupervision() WebsocketOption {
+	return func(ws *Websocket) {
+		ws.supervised = true
+	}
+}
+
+// WebsocketWithWelcomeTimeout sets timeout of waiting for welcome message after connecting to the eventsub server.
+// Connect returns ErrWelcomeTimeout if welcome message is not received in time. Zero timeout disables it.
+//
+// Default value is 10 seconds.
+func WebsocketWithWelcomeTimeout(timeout time.Duration) WebsocketOption {
+	return func(ws *Websocket) {
+		ws.welcomeTimeout = timeout
+	}
+}
+
+// WebsocketWithDispatcher sets options of the dispatcher that runs event handlers, e.g. to run them by a bounded
+// worker pool instead of a goroutine per event.
+//
+// Default value is a dispatcher that runs each handler in a separate goroutine.
+func WebsocketWithDispatcher(options ...DispatcherOption) WebsocketOption {
+	return func(ws *Websocket) {
+		applyDispatcherOptions(&ws.dispatcher, options)
+	}
+}
@@ -0,0 +1,227 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// websocketMaxSubscriptions is a maximum number of enabled subscriptions per websocket session.
+	websocketMaxSubscriptions = 300
+	// websocketMaxSessions is a maximum number of websocket sessions per client id and user.
+	websocketMaxSessions = 3
+	// websocketMaxTotalCost is a maximum total cost of websocket subscriptions per client id and user.
+	websocketMaxTotalCost = 10
+)
+
+// ErrWebsocketPoolFull indicates that all sessions of WebsocketPool have no capacity for a new subscription and no
+// more sessions can be opened.
+var ErrWebsocketPoolFull = errors.New("websocket pool is full")
+
+// WebsocketPoolUsage is a current usage of WebsocketPool capacity.
+type WebsocketPoolUsage struct {
+	// Sessions is a number of opened websocket sessions.
+	Sessions int
+	// MaxSessions is a maximum number of websocket sessions.
+	MaxSessions int
+	// Subscriptions is a number of subscriptions across all sessions.
+	Subscriptions int
+	// MaxSubscriptions is a maximum number of subscriptions across all sessions.
+	MaxSubscriptions int
+	// TotalCost is a total cost of subscriptions across all sessions.
+	TotalCost int
+	// MaxTotalCost is a maximum total cost of subscriptions of the user.
+	MaxTotalCost int
+}
+
+// WebsocketPool is a pool of Websocket clients of the same user that spreads subscriptions across websocket sessions
+// according to Twitch limits, so you can follow more channels than a single session allows. Sessions are opened on
+// demand and are kept connected until the pool is disconnected.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/handling-websocket-events/#subscription-limits.
+type WebsocketPool struct {
+	eventSub         *EventSub
+	websocketOptions []WebsocketOption
+
+	maxSessions      int
+	maxSubscriptions int
+	maxTotalCost     int
+	reconnectDelay   time.Duration
+
+	sessions []*websocketPoolSession
+	mu       sync.Mutex
+
+	lifecycleCtx  context.Context
+	stopLifecycle context.CancelFunc
+
+	onWebsocket func(*Websocket)
+	onError     func(error)
+}
+
+// websocketPoolSession is a Websocket of the pool with number of subscriptions that are being created on it.
+type websocketPoolSession struct {
+	websocket *Websocket
+	reserved  int
+}
+
+func newWebsocketPool(eventSub *EventSub, options ...WebsocketPoolOption) *WebsocketPool {
+	lifecycleCtx, stopLifecycle := context.WithCancel(context.Background())
+
+	pool := &WebsocketPool{
+		eventSub:         eventSub,
+		maxSessions:      websocketMaxSessions,
+		maxSubscriptions: websocketMaxSubscriptions,
+		maxTotalCost:     websocketMaxTotalCost,
+		reconnectDelay:   1 * time.Second,
+		lifecycleCtx:     lifecycleCtx,
+		stopLifecycle:    stopLifecycle,
+	}
+
+	for _, option := range options {
+		option(pool)
+	}
+
+	return pool
+}
+
+// Subscribe places subscription on a session with remaining capacity, opening a new session if needed, and creates
+// it with Websocket.Subscribe.
+//
+// ErrWebsocketPoolFull is returned if there is no capacity left.
+func (wp *WebsocketPool) Subscribe(ctx context.Context, subscription WebsocketSubscription) error {
+	session, err := wp.reserve(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		wp.mu.Lock()
+		session.reserved--
+		wp.mu.Unlock()
+	}()
+
+	if err = session.websocket.waitSession(ctx); err != nil {
+		return fmt.Errorf("wait session: %w", err)
+	}
+
+	return session.websocket.Subscribe(ctx, subscription)
+}
+
+// Usage returns current usage of the pool capacity.
+func (wp *WebsocketPool) Usage() WebsocketPoolUsage {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	usage := WebsocketPoolUsage{
+		Sessions:         len(wp.sessions),
+		MaxSessions:      wp.maxSessions,
+		MaxSubscriptions: wp.maxSessions * wp.maxSubscriptions,
+		MaxTotalCost:     wp.maxTotalCost,
+	}
+
+	for _, session := range wp.sessions {
+		subscriptions, cost := session.websocket.subscriptionsUsage()
+
+		usage.Subscriptions += subscriptions
+		usage.TotalCost += cost
+	}
+
+	return usage
+}
+
+// Websockets returns Websocket clients of all opened sessions.
+func (wp *WebsocketPool) Websockets() []*Websocket {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	websockets := make([]*Websocket, 0, len(wp.sessions))
+	for _, session := range wp.sessions {
+		websockets = append(websockets, session.websocket)
+	}
+
+	return websockets
+}
+
+// Disconnect disconnects all sessions of the pool. Pool can't be used after that.
+func (wp *WebsocketPool) Disconnect() error {
+	wp.stopLifecycle()
+
+	var errs []error
+
+	for _, websocket := range wp.Websockets() {
+		if err := websocket.Disconnect(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reserve reserves capacity for a subscription on the first session that has it, or on a new session.
+func (wp *WebsocketPool) reserve(ctx context.Context) (*websocketPoolSession, error) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if err := wp.lifecycleCtx.Err(); err != nil {
+		return nil, fmt.Errorf("pool is disconnected: %w", err)
+	}
+
+	var totalCost int
+
+	// Cost of subscriptions that are being created is unknown until they are created, so each of them is counted as 1,
+	// otherwise concurrent calls would all pass the check and exceed the limit.
+	for _, session := range wp.sessions {
+		_, cost := session.websocket.subscriptionsUsage()
+		totalCost += cost + session.reserved
+	}
+
+	if totalCost >= wp.maxTotalCost {
+		return nil, ErrWebsocketPoolFull
+	}
+
+	for _, session := range wp.sessions {
+		subscriptions, _ := session.websocket.subscriptionsUsage()
+
+		if subscriptions+session.reserved < wp.maxSubscriptions {
+			session.reserved++
+			return session, nil
+		}
+	}
+
+	if len(wp.sessions) >= wp.maxSessions {
+		return nil, ErrWebsocketPoolFull
+	}
+
+	session := &websocketPoolSession{
+		websocket: wp.eventSub.Websocket(wp.websocketOptions...),
+		reserved:  1,
+	}
+
+	if wp.onWebsocket != nil {
+		wp.onWebsocket(session.websocket)
+	}
+
+	wp.sessions = append(wp.sessions, session)
+
+	go wp.keepConnected(session.websocket)
+
+	return session, nil
+}
+
+// keepConnected blocks on connecting Websocket again each time it's disconnected until the pool is disconnected.
+func (wp *WebsocketPool) keepConnected(websocket *Websocket) {
+	for {
+		if err := websocket.Connect(wp.lifecycleCtx); err != nil && wp.onError != nil {
+			go wp.onError(fmt.Errorf("connect: %w", err))
+		}
+
+		select {
+		case <-wp.lifecycleCtx.Done():
+			return
+		case <-time.After(wp.reconnectDelay):
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package eventsub
+
+import (
+	"time"
+)
+
+// WebsocketPoolOption is an optional setting for WebsocketPool.
+type WebsocketPoolOption func(*WebsocketPool)
+
+// WebsocketPoolWithWebsocketOptions sets options of the Websocket clients opened by the pool. Websocket clients must
+// have Subscriber set with WebsocketWithSubscriber to create subscriptions.
+func WebsocketPoolWithWebsocketOptions(options ...WebsocketOption) WebsocketPoolOption {
+	return func(wp *WebsocketPool) {
+		wp.websocketOptions = options
+	}
+}
+
+// WebsocketPoolWithOnWebsocket sets callback that invokes synchronously when new Websocket client is opened by the
+// pool before it's connected, so you can set its event callbacks.
+func WebsocketPoolWithOnWebsocket(onWebsocket func(*Websocket)) WebsocketPoolOption {
+	return func(wp *WebsocketPool) {
+		wp.onWebsocket = onWebsocket
+	}
+}
+
+// WebsocketPoolWithOnError sets callback that invokes when Websocket client of the pool fails to connect.
+func WebsocketPoolWithOnError(onError func(error)) WebsocketPoolOption {
+	return func(wp *WebsocketPool) {
+		wp.onError = onError
+	}
+}
+
+// WebsocketPoolWithMaxSessions sets maximum number of websocket sessions the pool opens.
+//
+// Default value is 3 (Twitch's limit of sessions per client id and user).
+func WebsocketPoolWithMaxSessions(sessions int) WebsocketPoolOption {
+	return func(wp *WebsocketPool) {
+		wp.maxSessions = sessions
+	}
+}
+
+// WebsocketPoolWithMaxSubscriptions sets maximum number of subscriptions placed on a single websocket session.
+//
+// Default value is 300 (Twitch's limit of enabled subscriptions per session).
+func WebsocketPoolWithMaxSubscriptions(subscriptions int) WebsocketPoolOption {
+	return func(wp *WebsocketPool) {
+		wp.maxSubscriptions = subscriptions
+	}
+}
+
+// WebsocketPoolWithMaxTotalCost sets maximum total cost of subscriptions across all sessions.
+//
+// Default value is 10 (Twitch's limit of total cost per client id and user).
+func WebsocketPoolWithMaxTotalCost(cost int) WebsocketPoolOption {
+	return func(wp *WebsocketPool) {
+		wp.maxTotalCost = cost
+	}
+}
+
+// WebsocketPoolWithReconnectDelay sets delay between attempts to connect Websocket client after it has been
+// disconnected.
+//
+// Default value is 1 second.
+func WebsocketPoolWithReconnectDelay(delay time.Duration) WebsocketPoolOption {
+	return func(wp *WebsocketPool) {
+		wp.reconnectDelay = delay
+	}
+}
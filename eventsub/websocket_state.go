@@ -0,0 +1,131 @@
+package eventsub
+
+import "github.com/coder/websocket"
+
+// WebsocketState is a state of the Websocket connection.
+type WebsocketState int32
+
+const (
+	// WebsocketStateIdle is a state of the client that has never been connected.
+	WebsocketStateIdle WebsocketState = iota
+	// WebsocketStateDialing is a state of the client that is connecting to the eventsub server.
+	WebsocketStateDialing
+	// WebsocketStateAwaitingWelcome is a state of the client that is connected to the eventsub server and waiting for
+	// the welcome message.
+	WebsocketStateAwaitingWelcome
+	// WebsocketStateConnected is a state of the client that received the welcome message and has a session.
+	WebsocketStateConnected
+	// WebsocketStateReconnecting is a state of the client that is swapping the edge server according to the reconnect
+	// message flow or expired keepalive.
+	WebsocketStateReconnecting
+	// WebsocketStateClosed is a state of the client that has been disconnected, it can be connected again.
+	WebsocketStateClosed
+)
+
+func (s WebsocketState) String() string {
+	switch s {
+	case WebsocketStateIdle:
+		return "idle"
+	case WebsocketStateDialing:
+		return "dialing"
+	case WebsocketStateAwaitingWelcome:
+		return "awaiting_welcome"
+	case WebsocketStateConnected:
+		return "connected"
+	case WebsocketStateReconnecting:
+		return "reconnecting"
+	case WebsocketStateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// IsActive returns true if the client is running (i.e. state is neither idle nor closed).
+func (s WebsocketState) IsActive() bool {
+	return s != WebsocketStateIdle && s != WebsocketStateClosed
+}
+
+// canTransitionTo returns true if the state can be changed to the provided one.
+func (s WebsocketState) canTransitionTo(to WebsocketState) bool {
+	switch s {
+	case WebsocketStateIdle, WebsocketStateClosed:
+		return to == WebsocketStateDialing
+	case WebsocketStateDialing:
+		return to == WebsocketStateAwaitingWelcome || to == WebsocketStateClosed
+	case WebsocketStateAwaitingWelcome:
+		return to == WebsocketStateConnected || to == WebsocketStateClosed
+	case WebsocketStateConnected:
+		return to == WebsocketStateReconnecting || to == WebsocketStateClosed
+	case WebsocketStateReconnecting:
+		return to == WebsocketStateConnected || to == WebsocketStateClosed
+	default:
+		return false
+	}
+}
+
+// State returns current state of the connection.
+func (ws *Websocket) State() WebsocketState {
+	ws.stateMu.Lock()
+	defer ws.stateMu.Unlock()
+
+	return ws.state
+}
+
+// OnStateChange invokes when state of the connection is changed. Cause is an error that caused transition, if any.
+//
+// Unlike other callbacks, it is called synchronously in order of transitions, so it must not block.
+func (ws *Websocket) OnStateChange(onStateChange func(from, to WebsocketState, cause error)) {
+	ws.stateMu.Lock()
+	defer ws.stateMu.Unlock()
+
+	ws.onStateChange = onStateChange
+}
+
+// transition changes state of the connection if it's allowed from the current state. Guard is called under the lock
+// before changing state, so it can mutate connection fields atomically with transition or abort it by returning false.
+func (ws *Websocket) transition(to WebsocketState, cause error, guard func() bool) bool {
+	ws.stateMu.Lock()
+
+	from := ws.state
+
+	ok := from.canTransitionTo(to) && (guard == nil || guard())
+	if !ok {
+		ws.stateMu.Unlock()
+		return false
+	}
+
+	ws.state = to
+
+	onStateChange := ws.onStateChange
+	if onStateChange == nil {
+		ws.stateMu.Unlock()
+		return true
+	}
+
+	// Callback is called without the lock, so it can read the state, while callbacks of concurrent transitions wait
+	// for each other to be called in order of transitions.
+	previous := ws.lastStateChange
+	done := make(chan struct{})
+	ws.lastStateChange = done
+
+	ws.stateMu.Unlock()
+
+	defer close(done)
+
+	if previous != nil {
+		<-previous
+	}
+
+	onStateChange(from, to, cause)
+
+	return true
+}
+
+// connection returns current connection to the eventsub server.
+func (ws *Websocket) connection() *websocket.Conn {
+	ws.stateMu.Lock()
+	defer ws.stateMu.Unlock()
+
+	return ws.conn
+}
@@ -0,0 +1,291 @@
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/twirapp/twitchy/internal/json"
+)
+
+var (
+	// ErrNoSubscriber indicates that Websocket has no Subscriber to create subscriptions with.
+	ErrNoSubscriber = errors.New("subscriber is not set")
+	// ErrNoSession indicates that Websocket has not received welcome message yet, so there is no session to subscribe.
+	ErrNoSession = errors.New("websocket session is not established")
+)
+
+// resubscribeTimeout is a timeout of recreating each subscription for the new websocket session.
+const resubscribeTimeout = 30 * time.Second
+
+// WebsocketSubscription is a subscription of the websocket session to the event.
+type WebsocketSubscription struct {
+	Type      EventType
+	Version   string
+	Condition Condition
+}
+
+// Subscriber creates eventsub subscriptions with websocket transport on behalf of Websocket (see helix
+// WebsocketSubscriber).
+type Subscriber interface {
+	// Subscribe creates subscription for the websocket session in the transport and returns its cost.
+	Subscribe(ctx context.Context, subscription WebsocketSubscription, transport WebsocketTransport) (int, error)
+}
+
+// websocketSubscriptionEntry is a subscription remembered by Websocket with its identity key and cost.
+type websocketSubscriptionEntry struct {
+	subscription WebsocketSubscription
+	key          string
+	cost         int
+}
+
+// Subscribe creates subscription for the current websocket session with Subscriber and remembers it, so it will be
+// recreated automatically when Websocket is connected to a new session (e.g. after Connect is called again). Twitch
+// carries subscriptions over on reconnect message flow, so they are not recreated in that case.
+//
+// If Websocket is connecting or reconnecting after the session was lost, Subscribe waits for the welcome message of the
+// new session until context is canceled. ErrNoSession is returned if Websocket is not connected.
+//
+// Subscription is forgotten when Twitch revokes it or ForgetSubscription is called.
+func (ws *Websocket) Subscribe(ctx context.Context, subscription WebsocketSubscription) error {
+	if ws.subscriber == nil {
+		return ErrNoSubscriber
+	}
+
+	key, err := websocketSubscriptionKey(subscription.Type, subscription.Version, subscription.Condition)
+	if err != nil {
+		return fmt.Errorf("subscription key: %w", err)
+	}
+
+	sessionId, err := ws.currentSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	cost, err := ws.subscriber.Subscribe(ctx, subscription, WebsocketTransport{
+		Method:    TransportMethodWebsocket,
+		SessionId: sessionId,
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+
+	ws.subscriptionsMu.Lock()
+	defer ws.subscriptionsMu.Unlock()
+
+	for _, entry := range ws.subscriptions {
+		if entry.key == key {
+			return nil
+		}
+	}
+
+	ws.subscriptions = append(ws.subscriptions, websocketSubscriptionEntry{
+		subscription: subscription,
+		key:          key,
+		cost:         cost,
+	})
+
+	return nil
+}
+
+// ForgetSubscription forgets subscription, so it will not be recreated on a new session. It doesn't delete the
+// subscription of the current session.
+func (ws *Websocket) ForgetSubscription(subscription WebsocketSubscription) {
+	key, err := websocketSubscriptionKey(subscription.Type, subscription.Version, subscription.Condition)
+	if err != nil {
+		return
+	}
+
+	ws.forgetSubscription(key)
+}
+
+// Subscriptions returns subscriptions remembered by Websocket.
+func (ws *Websocket) Subscriptions() []WebsocketSubscription {
+	ws.subscriptionsMu.Lock()
+	defer ws.subscriptionsMu.Unlock()
+
+	subscriptions := make([]WebsocketSubscription, 0, len(ws.subscriptions))
+	for _, entry := range ws.subscriptions {
+		subscriptions = append(subscriptions, entry.subscription)
+	}
+
+	return subscriptions
+}
+
+// waitSession blocks until Websocket receives welcome message of the new session or context is canceled.
+func (ws *Websocket) waitSession(ctx context.Context) error {
+	ws.subscriptionsMu.Lock()
+	sessionReady := ws.sessionReady
+	ws.subscriptionsMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-sessionReady:
+		return nil
+	}
+}
+
+// currentSession returns id of the current websocket session, waiting for it if Websocket is active, or returns
+// ErrNoSession if Websocket is not connected.
+func (ws *Websocket) currentSession(ctx context.Context) (string, error) {
+	for {
+		ws.subscriptionsMu.Lock()
+		sessionId := ws.sessionId
+		ws.subscriptionsMu.Unlock()
+
+		if sessionId != "" {
+			return sessionId, nil
+		}
+
+		if !ws.State().IsActive() {
+			return "", ErrNoSession
+		}
+
+		if err := ws.waitSession(ctx); err != nil {
+			return "", err
+		}
+	}
+}
+
+// resetSession forgets id of the lost websocket session, so subscriptions wait for the welcome message of the new one.
+func (ws *Websocket) resetSession() {
+	ws.subscriptionsMu.Lock()
+	defer ws.subscriptionsMu.Unlock()
+
+	ws.sessionId = ""
+
+	if isClosed(ws.sessionReady) {
+		ws.sessionReady = make(chan struct{})
+	}
+}
+
+// subscriptionsUsage returns number and total cost of subscriptions remembered by Websocket.
+func (ws *Websocket) subscriptionsUsage() (int, int) {
+	ws.subscriptionsMu.Lock()
+	defer ws.subscriptionsMu.Unlock()
+
+	var cost int
+	for _, entry := range ws.subscriptions {
+		cost += entry.cost
+	}
+
+	return len(ws.subscriptions), cost
+}
+
+// setSession sets id of the current websocket session and recreates remembered subscriptions if it's a new session.
+func (ws *Websocket) setSession(ctx context.Context, sessionId string) {
+	ws.subscriptionsMu.Lock()
+
+	isNewSession := ws.sessionId != sessionId
+	ws.sessionId = sessionId
+
+	subscriptions := slices.Clone(ws.subscriptions)
+
+	if !isClosed(ws.sessionReady) {
+		close(ws.sessionReady)
+	}
+
+	ws.subscriptionsMu.Unlock()
+
+	if !isNewSession || ws.subscriber == nil || len(subscriptions) == 0 {
+		return
+	}
+
+	// Connection context is canceled on reconnect, while welcome message after reconnect has the same session id, so
+	// subscriptions that are not recreated by then would never be retried.
+	go ws.resubscribe(context.WithoutCancel(ctx), sessionId, subscriptions)
+}
+
+// resubscribe recreates subscriptions for the websocket session, updates their costs and reports failed ones. It stops
+// once the session is lost, as subscriptions are recreated again for the next session.
+func (ws *Websocket) resubscribe(ctx context.Context, sessionId string, entries []websocketSubscriptionEntry) {
+	transport := WebsocketTransport{
+		Method:    TransportMethodWebsocket,
+		SessionId: sessionId,
+	}
+
+	for _, entry := range entries {
+		if !ws.isCurrentSession(sessionId) {
+			return
+		}
+
+		subscribeCtx, cancel := context.WithTimeout(ctx, resubscribeTimeout)
+		cost, err := ws.subscriber.Subscribe(subscribeCtx, entry.subscription, transport)
+		cancel()
+
+		if err != nil {
+			if ws.onResubscribeError != nil {
+				go ws.onResubscribeError(entry.subscription, err)
+			}
+
+			continue
+		}
+
+		ws.setSubscriptionCost(entry.key, cost)
+	}
+}
+
+// isCurrentSession returns if the session is the current websocket session.
+func (ws *Websocket) isCurrentSession(sessionId string) bool {
+	ws.subscriptionsMu.Lock()
+	defer ws.subscriptionsMu.Unlock()
+
+	return ws.sessionId == sessionId
+}
+
+// setSubscriptionCost updates cost of the remembered subscription, if it's not forgotten yet.
+func (ws *Websocket) setSubscriptionCost(key string, cost int) {
+	ws.subscriptionsMu.Lock()
+	defer ws.subscriptionsMu.Unlock()
+
+	for i, entry := range ws.subscriptions {
+		if entry.key == key {
+			ws.subscriptions[i].cost = cost
+			return
+		}
+	}
+}
+
+func (ws *Websocket) forgetSubscription(key string) {
+	ws.subscriptionsMu.Lock()
+	defer ws.subscriptionsMu.Unlock()
+
+	for i, entry := range ws.subscriptions {
+		if entry.key == key {
+			ws.subscriptions = append(ws.subscriptions[:i], ws.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// websocketSubscriptionKey returns key that identifies subscription by its type, version and condition.
+func websocketSubscriptionKey(eventType EventType, version string, condition any) (string, error) {
+	var anyCondition AnyCondition
+
+	// Condition is converted to AnyCondition, so typed and raw conditions of the same subscription have the same key.
+	payload, err := json.Marshal(condition)
+	if err != nil {
+		return "", fmt.Errorf("marshal condition: %w", err)
+	}
+
+	if err = json.Unmarshal(payload, &anyCondition); err != nil {
+		return "", fmt.Errorf("unmarshal condition: %w", err)
+	}
+
+	for field, value := range anyCondition {
+		if value == "" {
+			delete(anyCondition, field)
+		}
+	}
+
+	// Map keys are sorted by JSON marshaller, so the key is deterministic.
+	payload, err = json.Marshal(anyCondition)
+	if err != nil {
+		return "", fmt.Errorf("marshal any condition: %w", err)
+	}
+
+	return eventType.String() + "|" + version + "|" + string(payload), nil
+}
@@ -0,0 +1,151 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/twirapp/twitchy/eventsub"
+)
+
+// Announcement colors.
+const (
+	AnnouncementColorPrimary = "primary"
+	AnnouncementColorBlue    = "blue"
+	AnnouncementColorGreen   = "green"
+	AnnouncementColorOrange  = "orange"
+	AnnouncementColorPurple  = "purple"
+)
+
+// SendChatMessageParams is a set of parameters of the chat message to send.
+type SendChatMessageParams struct {
+	// BroadcasterId is an id of the broadcaster whose chat room the message will be sent to.
+	BroadcasterId string `json:"broadcaster_id"`
+	// SenderId is an id of the user sending the message, it must match the user in the access token.
+	SenderId string `json:"sender_id"`
+	// Message is a message to send, limited to 500 characters.
+	Message string `json:"message"`
+	// ReplyParentMessageId is an id of the chat message being replied to (e.g. eventsub.ChannelChatMessageEvent
+	// MessageId).
+	ReplyParentMessageId string `json:"reply_parent_message_id,omitempty"`
+	// ForSourceOnly determines if the message is sent only to the source channel during a shared chat session.
+	ForSourceOnly *bool `json:"for_source_only,omitempty"`
+}
+
+// ReplyTo returns parameters of the message that replies to the chat message event in the same chat room.
+func ReplyTo(event eventsub.ChannelChatMessageEvent, senderId, message string) SendChatMessageParams {
+	return SendChatMessageParams{
+		BroadcasterId:        event.BroadcasterUserId,
+		SenderId:             senderId,
+		Message:              message,
+		ReplyParentMessageId: event.MessageId,
+	}
+}
+
+// ChatDropReason is a reason why the chat message was dropped.
+type ChatDropReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (dr *ChatDropReason) Error() string {
+	return "chat message dropped: " + dr.Code + ": " + dr.Message
+}
+
+// SentChatMessage is a result of sending chat message. Message may be dropped by Twitch (e.g. by AutoMod), in that
+// case IsSent is false and DropReason explains why.
+type SentChatMessage struct {
+	MessageId  string          `json:"message_id"`
+	IsSent     bool            `json:"is_sent"`
+	DropReason *ChatDropReason `json:"drop_reason"`
+}
+
+// SendChatAnnouncementParams is a set of parameters of the announcement to send.
+type SendChatAnnouncementParams struct {
+	// BroadcasterId is an id of the broadcaster whose chat room the announcement will be sent to.
+	BroadcasterId string `json:"-"`
+	// ModeratorId is an id of the broadcaster or moderator sending the announcement, it must match the user in the
+	// access token.
+	ModeratorId string `json:"-"`
+	// Message is an announcement to send, limited to 500 characters.
+	Message string `json:"message"`
+	// Color is a color of the announcement (e.g. AnnouncementColorPurple), primary color is used by default.
+	Color string `json:"color,omitempty"`
+}
+
+// SendChatMessage sends message to the chat room. Messages dropped by Twitch are not considered as errors and are
+// reported with SentChatMessage DropReason instead.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#send-chat-message.
+func (h *Helix) SendChatMessage(ctx context.Context, params SendChatMessageParams) (SentChatMessage, error) {
+	var response struct {
+		Data []SentChatMessage `json:"data"`
+	}
+
+	err := h.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/chat/messages",
+		body:   params,
+	}, &response)
+	if err != nil {
+		return SentChatMessage{}, err
+	}
+
+	if len(response.Data) == 0 {
+		return SentChatMessage{}, ErrEmptyResponse
+	}
+
+	return response.Data[0], nil
+}
+
+// SendChatAnnouncement sends announcement to the chat room.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#send-chat-announcement.
+func (h *Helix) SendChatAnnouncement(ctx context.Context, params SendChatAnnouncementParams) error {
+	return h.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/chat/announcements",
+		query: url.Values{
+			"broadcaster_id": {params.BroadcasterId},
+			"moderator_id":   {params.ModeratorId},
+		},
+		body: params,
+	}, nil)
+}
+
+// SendShoutout sends shoutout of the broadcaster to the chat room of another broadcaster. The moderatorId must match
+// the user in the access token.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#send-a-shoutout.
+func (h *Helix) SendShoutout(ctx context.Context, fromBroadcasterId, toBroadcasterId, moderatorId string) error {
+	return h.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/chat/shoutouts",
+		query: url.Values{
+			"from_broadcaster_id": {fromBroadcasterId},
+			"to_broadcaster_id":   {toBroadcasterId},
+			"moderator_id":        {moderatorId},
+		},
+	}, nil)
+}
+
+// DeleteChatMessages deletes chat message with the provided id from the chat room, or all messages if messageId is
+// empty. The moderatorId must match the user in the access token.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#delete-chat-messages.
+func (h *Helix) DeleteChatMessages(ctx context.Context, broadcasterId, moderatorId, messageId string) error {
+	query := url.Values{
+		"broadcaster_id": {broadcasterId},
+		"moderator_id":   {moderatorId},
+	}
+
+	if messageId != "" {
+		query.Set("message_id", messageId)
+	}
+
+	return h.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/moderation/chat",
+		query:  query,
+	}, nil)
+}
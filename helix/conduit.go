@@ -0,0 +1,283 @@
+package helix
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/twirapp/twitchy/eventsub"
+)
+
+// Conduit shard statuses.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#get-conduit-shards.
+const (
+	ConduitShardStatusEnabled                            = "enabled"
+	ConduitShardStatusWebhookCallbackVerificationPending = "webhook_callback_verification_pending"
+	ConduitShardStatusWebhookCallbackVerificationFailed  = "webhook_callback_verification_failed"
+	ConduitShardStatusNotificationFailuresExceeded       = "notification_failures_exceeded"
+	ConduitShardStatusWebsocketDisconnected              = "websocket_disconnected"
+	ConduitShardStatusWebsocketFailedPingPong            = "websocket_failed_ping_pong"
+	ConduitShardStatusWebsocketReceivedInboundTraffic    = "websocket_received_inbound_traffic"
+	ConduitShardStatusWebsocketInternalError             = "websocket_internal_error"
+	ConduitShardStatusWebsocketNetworkTimeout            = "websocket_network_timeout"
+	ConduitShardStatusWebsocketNetworkError              = "websocket_network_error"
+	ConduitShardStatusWebsocketFailedToReconnect         = "websocket_failed_to_reconnect"
+)
+
+// Conduit is an eventsub conduit that routes subscription events to its shards.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/handling-conduit-events.
+type Conduit struct {
+	Id         string `json:"id"`
+	ShardCount int    `json:"shard_count"`
+}
+
+// ConduitShardTransport is a transport of the conduit shard.
+type ConduitShardTransport struct {
+	eventsub.AnyTransport
+	// ConnectedAt is a time when the websocket session was connected, it's set only for websocket transport.
+	ConnectedAt *eventsub.TimestampUTC `json:"connected_at,omitempty"`
+	// DisconnectedAt is a time when the websocket session was disconnected, it's set only for websocket transport.
+	DisconnectedAt *eventsub.TimestampUTC `json:"disconnected_at,omitempty"`
+}
+
+// ConduitShard is a shard of the conduit.
+type ConduitShard struct {
+	Id        string                `json:"id"`
+	Status    string                `json:"status"`
+	Transport ConduitShardTransport `json:"transport"`
+}
+
+// ConduitShardUpdate is an update of the conduit shard transport.
+type ConduitShardUpdate struct {
+	// Id is a numeric string of the shard to update.
+	Id        string                `json:"id"`
+	Transport eventsub.AnyTransport `json:"transport"`
+}
+
+// WebsocketConduitShardUpdate returns shard update that routes the shard events to the websocket session, e.g. with
+// session id received in eventsub.WebsocketWelcomeMessage.
+func WebsocketConduitShardUpdate(shardId, sessionId string) ConduitShardUpdate {
+	return ConduitShardUpdate{
+		Id: shardId,
+		Transport: eventsub.AnyTransport{
+			Method:    eventsub.TransportMethodWebsocket,
+			SessionId: sessionId,
+		},
+	}
+}
+
+// WebhookConduitShardUpdate returns shard update that routes the shard events to the webhook callback.
+func WebhookConduitShardUpdate(shardId, callback, secret string) ConduitShardUpdate {
+	return ConduitShardUpdate{
+		Id: shardId,
+		Transport: eventsub.AnyTransport{
+			Method:   eventsub.TransportMethodWebhook,
+			Callback: callback,
+			Secret:   secret,
+		},
+	}
+}
+
+// ConduitShardError is an error of the conduit shard that was failed to update.
+type ConduitShardError struct {
+	// Id is an id of the shard that was failed to update.
+	Id      string `json:"id"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func (e ConduitShardError) Error() string {
+	return "shard " + e.Id + ": " + e.Message
+}
+
+// UpdatedConduitShards is a result of conduit shards update. Shards that were failed to update are reported in Errors
+// instead of the returned error as the rest of shards are updated anyway.
+type UpdatedConduitShards struct {
+	Shards []ConduitShard      `json:"data"`
+	Errors []ConduitShardError `json:"errors"`
+}
+
+// GetConduitShardsParams is a set of filters for GetConduitShards.
+type GetConduitShardsParams struct {
+	// ConduitId is an id of the conduit to get shards of, it's required.
+	ConduitId string
+	// Status filters shards by status (e.g. ConduitShardStatusEnabled).
+	Status string
+	// After is a cursor used to get the next page of results.
+	After string
+}
+
+// ConduitShards is a page of conduit shards.
+type ConduitShards struct {
+	Shards []ConduitShard
+	// Cursor is a cursor of the next page, it's empty if there are no more pages.
+	Cursor string
+}
+
+type (
+	conduitsResponse struct {
+		Data []Conduit `json:"data"`
+	}
+
+	conduitShardsResponse struct {
+		Data       []ConduitShard `json:"data"`
+		Pagination Pagination     `json:"pagination"`
+	}
+)
+
+// GetConduits returns conduits of the client in the app access token.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#get-conduits.
+func (h *Helix) GetConduits(ctx context.Context) ([]Conduit, error) {
+	var response conduitsResponse
+
+	err := h.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/eventsub/conduits",
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// CreateConduit creates a new conduit with the provided number of shards.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#create-conduits.
+func (h *Helix) CreateConduit(ctx context.Context, shardCount int) (Conduit, error) {
+	return h.doConduit(ctx, request{
+		method: http.MethodPost,
+		path:   "/eventsub/conduits",
+		body: map[string]int{
+			"shard_count": shardCount,
+		},
+	})
+}
+
+// UpdateConduit updates shard count of the conduit. Shards are removed from the end of the list when shard count is
+// decreased, so subscriptions routed to them will be lost.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#update-conduits.
+func (h *Helix) UpdateConduit(ctx context.Context, conduitId string, shardCount int) (Conduit, error) {
+	return h.doConduit(ctx, request{
+		method: http.MethodPatch,
+		path:   "/eventsub/conduits",
+		body: Conduit{
+			Id:         conduitId,
+			ShardCount: shardCount,
+		},
+	})
+}
+
+// DeleteConduit deletes conduit with the provided id.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#delete-conduit.
+func (h *Helix) DeleteConduit(ctx context.Context, conduitId string) error {
+	return h.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/eventsub/conduits",
+		query:  url.Values{"id": {conduitId}},
+	}, nil)
+}
+
+// GetConduitShards returns a page of the conduit shards.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#get-conduit-shards.
+func (h *Helix) GetConduitShards(ctx context.Context, params GetConduitShardsParams) (ConduitShards, error) {
+	query := url.Values{"conduit_id": {params.ConduitId}}
+
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	if params.After != "" {
+		query.Set("after", params.After)
+	}
+
+	var response conduitShardsResponse
+
+	err := h.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/eventsub/conduits/shards",
+		query:  query,
+	}, &response)
+	if err != nil {
+		return ConduitShards{}, err
+	}
+
+	return ConduitShards{
+		Shards: response.Data,
+		Cursor: response.Pagination.Cursor,
+	}, nil
+}
+
+// IterConduitShards returns iterator over conduit shards of all pages. The After of params is used as a cursor of
+// the first page.
+func (h *Helix) IterConduitShards(
+	ctx context.Context,
+	params GetConduitShardsParams,
+	options ...PaginateOption,
+) iter.Seq2[ConduitShard, error] {
+	firstCursor := params.After
+
+	return Paginate(ctx, func(ctx context.Context, cursor string, _ int) ([]ConduitShard, string, error) {
+		params.After = cursor
+		if cursor == "" {
+			params.After = firstCursor
+		}
+
+		page, err := h.GetConduitShards(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return page.Shards, page.Cursor, nil
+	}, options...)
+}
+
+// UpdateConduitShards updates transports of the conduit shards, e.g. to assign websocket session id received in
+// welcome message to the shard.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#update-conduit-shards.
+func (h *Helix) UpdateConduitShards(
+	ctx context.Context,
+	conduitId string,
+	shards []ConduitShardUpdate,
+) (UpdatedConduitShards, error) {
+	var response UpdatedConduitShards
+
+	err := h.do(ctx, request{
+		method: http.MethodPatch,
+		path:   "/eventsub/conduits/shards",
+		body: struct {
+			ConduitId string               `json:"conduit_id"`
+			Shards    []ConduitShardUpdate `json:"shards"`
+		}{
+			ConduitId: conduitId,
+			Shards:    shards,
+		},
+	}, &response)
+	if err != nil {
+		return UpdatedConduitShards{}, err
+	}
+
+	return response, nil
+}
+
+// doConduit sends request to the conduits endpoint that responds with a single conduit.
+func (h *Helix) doConduit(ctx context.Context, req request) (Conduit, error) {
+	var response conduitsResponse
+
+	if err := h.do(ctx, req, &response); err != nil {
+		return Conduit{}, err
+	}
+
+	if len(response.Data) == 0 {
+		return Conduit{}, ErrEmptyResponse
+	}
+
+	return response.Data[0], nil
+}
@@ -0,0 +1,34 @@
+package helix
+
+import (
+	"fmt"
+
+	"github.com/twirapp/twitchy/internal/json"
+)
+
+// APIError is an error returned by the Helix API with non-successful status code.
+type APIError struct {
+	// Status is an HTTP status code of the response.
+	Status int `json:"status"`
+	// Err is a short textual representation of the status code (e.g. "Bad Request").
+	Err string `json:"error"`
+	// Message is a detailed description of the error.
+	Message string `json:"message"`
+}
+
+func newAPIError(status int, body []byte) *APIError {
+	apiError := &APIError{}
+
+	// Body is not guaranteed to be a JSON object, so we just fall back to raw body as error message.
+	if err := json.Unmarshal(body, apiError); err != nil {
+		apiError.Message = string(body)
+	}
+
+	apiError.Status = status
+
+	return apiError
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("helix: %d %s: %s", e.Status, e.Err, e.Message)
+}
@@ -0,0 +1,175 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/twirapp/twitchy/eventsub"
+)
+
+// ErrEmptyResponse indicates that Helix responded successfully but without any data where it was expected.
+var ErrEmptyResponse = errors.New("empty response data")
+
+// EventSubCost is a cost information of eventsub subscriptions that is returned with subscriptions.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/manage-subscriptions/#subscription-limits.
+type EventSubCost struct {
+	// Total is a total number of subscriptions that you've created.
+	Total int `json:"total"`
+	// TotalCost is a sum of all of your subscription costs.
+	TotalCost int `json:"total_cost"`
+	// MaxTotalCost is a maximum total cost that you're allowed to incur for all subscriptions that you create.
+	MaxTotalCost int `json:"max_total_cost"`
+}
+
+// CreateEventSubSubscriptionRequest is a request to create eventsub subscription of the provided type and version with
+// typed condition and transport.
+type CreateEventSubSubscriptionRequest[C eventsub.Condition, T eventsub.Transport] struct {
+	Type      eventsub.EventType `json:"type"`
+	Version   string             `json:"version"`
+	Condition C                  `json:"condition"`
+	Transport T                  `json:"transport"`
+}
+
+// GetEventSubSubscriptionsParams is a set of filters for GetEventSubSubscriptions. Twitch allows to specify only one of
+// Status, Type, UserId or SubscriptionId filters per request.
+type GetEventSubSubscriptionsParams struct {
+	// Status filters subscriptions by status (e.g. eventsub.SubscriptionStatusEnabled).
+	Status string
+	// Type filters subscriptions by subscription type.
+	Type eventsub.EventType
+	// UserId filters subscriptions by user ID in the condition.
+	UserId string
+	// SubscriptionId filters subscriptions by subscription ID.
+	SubscriptionId string
+	// After is a cursor used to get the next page of results.
+	After string
+}
+
+// EventSubSubscriptions is a page of eventsub subscriptions of any type.
+type EventSubSubscriptions struct {
+	Subscriptions []eventsub.Subscription[eventsub.AnyCondition, eventsub.AnyTransport]
+	EventSubCost
+	// Cursor is a cursor of the next page, it's empty if there are no more pages.
+	Cursor string
+}
+
+type eventSubSubscriptionsResponse[C eventsub.Condition, T eventsub.Transport] struct {
+	Data []eventsub.Subscription[C, T] `json:"data"`
+	EventSubCost
+	Pagination Pagination `json:"pagination"`
+}
+
+// CreateEventSubSubscription creates eventsub subscription and returns it with the cost information. It's a function
+// instead of Helix method as Go doesn't support type parameters in methods.
+//
+// Webhook subscriptions require app access token, while websocket subscriptions require user access token.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#create-eventsub-subscription.
+func CreateEventSubSubscription[C eventsub.Condition, T eventsub.Transport](
+	ctx context.Context,
+	h *Helix,
+	req CreateEventSubSubscriptionRequest[C, T],
+) (eventsub.Subscription[C, T], EventSubCost, error) {
+	var response eventSubSubscriptionsResponse[C, T]
+
+	err := h.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/eventsub/subscriptions",
+		body:   req,
+	}, &response)
+	if err != nil {
+		return eventsub.Subscription[C, T]{}, EventSubCost{}, err
+	}
+
+	if len(response.Data) == 0 {
+		return eventsub.Subscription[C, T]{}, EventSubCost{}, ErrEmptyResponse
+	}
+
+	return response.Data[0], response.EventSubCost, nil
+}
+
+// GetEventSubSubscriptions returns a page of eventsub subscriptions that the client in the access token created.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#get-eventsub-subscriptions.
+func (h *Helix) GetEventSubSubscriptions(
+	ctx context.Context,
+	params GetEventSubSubscriptionsParams,
+) (EventSubSubscriptions, error) {
+	query := make(url.Values)
+
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	if params.Type != "" {
+		query.Set("type", params.Type.String())
+	}
+	if params.UserId != "" {
+		query.Set("user_id", params.UserId)
+	}
+	if params.SubscriptionId != "" {
+		query.Set("subscription_id", params.SubscriptionId)
+	}
+	if params.After != "" {
+		query.Set("after", params.After)
+	}
+
+	var response eventSubSubscriptionsResponse[eventsub.AnyCondition, eventsub.AnyTransport]
+
+	err := h.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/eventsub/subscriptions",
+		query:  query,
+	}, &response)
+	if err != nil {
+		return EventSubSubscriptions{}, err
+	}
+
+	return EventSubSubscriptions{
+		Subscriptions: response.Data,
+		EventSubCost:  response.EventSubCost,
+		Cursor:        response.Pagination.Cursor,
+	}, nil
+}
+
+// IterEventSubSubscriptions returns iterator over eventsub subscriptions of all pages. The After of params is used
+// as a cursor of the first page.
+func (h *Helix) IterEventSubSubscriptions(
+	ctx context.Context,
+	params GetEventSubSubscriptionsParams,
+	options ...PaginateOption,
+) iter.Seq2[eventsub.Subscription[eventsub.AnyCondition, eventsub.AnyTransport], error] {
+	firstCursor := params.After
+
+	return Paginate(ctx, func(
+		ctx context.Context,
+		cursor string,
+		_ int,
+	) ([]eventsub.Subscription[eventsub.AnyCondition, eventsub.AnyTransport], string, error) {
+		params.After = cursor
+		if cursor == "" {
+			params.After = firstCursor
+		}
+
+		page, err := h.GetEventSubSubscriptions(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return page.Subscriptions, page.Cursor, nil
+	}, options...)
+}
+
+// DeleteEventSubSubscription deletes eventsub subscription with the provided id.
+//
+// Reference: https://dev.twitch.tv/docs/api/reference/#delete-eventsub-subscription.
+func (h *Helix) DeleteEventSubSubscription(ctx context.Context, subscriptionId string) error {
+	return h.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/eventsub/subscriptions",
+		query:  url.Values{"id": {subscriptionId}},
+	}, nil)
+}
@@ -0,0 +1,312 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/twirapp/twitchy/eventsub"
+	"github.com/twirapp/twitchy/internal/json"
+)
+
+// DesiredSubscription is an eventsub subscription that should exist.
+type DesiredSubscription struct {
+	Type      eventsub.EventType
+	Version   string
+	Condition eventsub.AnyCondition
+	// Transport is a transport to create subscription with, its Secret is not compared with existing subscriptions.
+	Transport eventsub.AnyTransport
+}
+
+// NewDesiredSubscription returns DesiredSubscription with typed condition and transport converted to their raw form.
+func NewDesiredSubscription(
+	eventType eventsub.EventType,
+	version string,
+	condition eventsub.Condition,
+	transport eventsub.Transport,
+) (DesiredSubscription, error) {
+	var (
+		anyCondition eventsub.AnyCondition
+		anyTransport eventsub.AnyTransport
+	)
+
+	if err := convertJSON(condition, &anyCondition); err != nil {
+		return DesiredSubscription{}, fmt.Errorf("convert condition: %w", err)
+	}
+
+	if err := convertJSON(transport, &anyTransport); err != nil {
+		return DesiredSubscription{}, fmt.Errorf("convert transport: %w", err)
+	}
+
+	return DesiredSubscription{
+		Type:      eventType,
+		Version:   version,
+		Condition: anyCondition,
+		Transport: anyTransport,
+	}, nil
+}
+
+// ReconcilePlan is a set of actions that converge existing subscriptions with desired ones.
+type ReconcilePlan struct {
+	// Create is a list of desired subscriptions that don't exist or exist only in a stale state.
+	Create []DesiredSubscription
+	// Delete is a list of existing subscriptions that are not desired, duplicated or stale.
+	Delete []eventsub.Subscription[eventsub.AnyCondition, eventsub.AnyTransport]
+	// Keep is a list of existing subscriptions that match desired ones.
+	Keep []eventsub.Subscription[eventsub.AnyCondition, eventsub.AnyTransport]
+	// Unrecoverable is a list of existing desired subscriptions that can't be recreated until the user authorizes your
+	// app again or the desired subscription is changed (e.g. authorization revoked or version removed). They are
+	// neither deleted nor recreated.
+	Unrecoverable []eventsub.Subscription[eventsub.AnyCondition, eventsub.AnyTransport]
+}
+
+// IsEmpty returns if plan has no actions to apply.
+func (rp ReconcilePlan) IsEmpty() bool {
+	return len(rp.Create) == 0 && len(rp.Delete) == 0
+}
+
+// Reconciler converges eventsub subscriptions that Twitch has with the desired set of subscriptions. It creates missing
+// subscriptions, deletes subscriptions that are not desired and recreates subscriptions that are stale (e.g. failed
+// webhook callback verification or disconnected websocket). Subscriptions that can't be recreated (e.g. revoked) are
+// reported in ReconcilePlan.Unrecoverable.
+type Reconciler struct {
+	helix      *Helix
+	filter     GetEventSubSubscriptionsParams
+	dryRun     bool
+	keepExtras bool
+}
+
+func NewReconciler(helix *Helix, options ...ReconcilerOption) *Reconciler {
+	reconciler := &Reconciler{
+		helix: helix,
+	}
+
+	for _, option := range options {
+		option(reconciler)
+	}
+
+	return reconciler
+}
+
+// Reconcile plans and applies actions to converge existing subscriptions with desired ones and returns the plan. In
+// dry-run mode the plan is only returned without being applied.
+//
+// Errors of individual actions don't stop applying the rest of the plan and are returned joined.
+func (r *Reconciler) Reconcile(ctx context.Context, desired []DesiredSubscription) (ReconcilePlan, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return ReconcilePlan{}, err
+	}
+
+	if r.dryRun {
+		return plan, nil
+	}
+
+	return plan, r.Apply(ctx, plan)
+}
+
+// Plan lists existing subscriptions and diffs them against desired ones.
+func (r *Reconciler) Plan(ctx context.Context, desired []DesiredSubscription) (ReconcilePlan, error) {
+	var plan ReconcilePlan
+
+	desiredByKey := make(map[string]DesiredSubscription, len(desired))
+	for _, subscription := range desired {
+		key := subscriptionKey(subscription.Type, subscription.Version, subscription.Condition, subscription.Transport)
+		desiredByKey[key] = subscription
+	}
+
+	type listedSubscription struct {
+		subscription eventsub.Subscription[eventsub.AnyCondition, eventsub.AnyTransport]
+		key          string
+	}
+
+	var listed []listedSubscription
+
+	// winners maps key of the desired subscription to index of the listed subscription that is kept for it.
+	winners := make(map[string]int, len(desired))
+
+	for subscription, err := range r.helix.IterEventSubSubscriptions(ctx, r.filter) {
+		if err != nil {
+			return ReconcilePlan{}, fmt.Errorf("list subscriptions: %w", err)
+		}
+
+		key := subscriptionKey(
+			eventsub.EventType(subscription.Type),
+			subscription.Version,
+			subscription.Condition,
+			subscription.Transport,
+		)
+
+		listed = append(listed, listedSubscription{subscription: subscription, key: key})
+
+		if _, isDesired := desiredByKey[key]; !isDesired {
+			continue
+		}
+
+		// Active subscription is preferred over unrecoverable one regardless of their order in the list, while
+		// recoverable subscriptions are recreated.
+		rank := subscriptionRank(subscription.Status)
+		if rank == 0 {
+			continue
+		}
+
+		if winner, ok := winners[key]; !ok || rank > subscriptionRank(listed[winner].subscription.Status) {
+			winners[key] = len(listed) - 1
+		}
+	}
+
+	for i, entry := range listed {
+		subscription := entry.subscription
+
+		if _, isDesired := desiredByKey[entry.key]; !isDesired {
+			if !r.keepExtras {
+				plan.Delete = append(plan.Delete, subscription)
+			}
+
+			continue
+		}
+
+		winner, hasWinner := winners[entry.key]
+
+		switch {
+		case !hasWinner || winner != i:
+			plan.Delete = append(plan.Delete, subscription)
+		case !isActiveSubscription(subscription.Status):
+			// Recreating subscription would fail until the user authorizes the app again, so it's not created.
+			plan.Unrecoverable = append(plan.Unrecoverable, subscription)
+		default:
+			plan.Keep = append(plan.Keep, subscription)
+		}
+	}
+
+	kept := make(map[string]struct{}, len(desired))
+	for key := range winners {
+		kept[key] = struct{}{}
+	}
+
+	for _, subscription := range desired {
+		key := subscriptionKey(subscription.Type, subscription.Version, subscription.Condition, subscription.Transport)
+
+		if _, ok := kept[key]; ok {
+			continue
+		}
+
+		// Mark as kept to not create duplicates of the same desired subscription.
+		kept[key] = struct{}{}
+		plan.Create = append(plan.Create, subscription)
+	}
+
+	return plan, nil
+}
+
+// Apply deletes and creates subscriptions according to the plan.
+func (r *Reconciler) Apply(ctx context.Context, plan ReconcilePlan) error {
+	var errs []error
+
+	for _, subscription := range plan.Delete {
+		if err := r.helix.DeleteEventSubSubscription(ctx, subscription.Id); err != nil {
+			errs = append(errs, fmt.Errorf("delete subscription %s: %w", subscription.Id, err))
+		}
+	}
+
+	for _, subscription := range plan.Create {
+		_, _, err := CreateEventSubSubscription(ctx, r.helix, CreateEventSubSubscriptionRequest[
+			eventsub.AnyCondition,
+			eventsub.AnyTransport,
+		]{
+			Type:      subscription.Type,
+			Version:   subscription.Version,
+			Condition: subscription.Condition,
+			Transport: subscription.Transport,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create subscription %s v%s: %w", subscription.Type, subscription.Version, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isActiveSubscription returns if subscription with the status delivers or is about to deliver events.
+func isActiveSubscription(status string) bool {
+	return status == eventsub.SubscriptionStatusEnabled ||
+		status == eventsub.SubscriptionStatusWebhookCallbackVerificationPending
+}
+
+// subscriptionRank returns priority of keeping subscription with the status among duplicates: 2 for active, 1 for
+// unrecoverable and 0 for recoverable subscriptions that are never kept.
+func subscriptionRank(status string) int {
+	switch {
+	case isActiveSubscription(status):
+		return 2
+	case isRecoverableSubscription(status):
+		return 0
+	default:
+		return 1
+	}
+}
+
+// isRecoverableSubscription returns if subscription with the status will never deliver events again, but can be
+// recreated with the same authorization (i.e. it failed because of the transport).
+func isRecoverableSubscription(status string) bool {
+	switch status {
+	case eventsub.SubscriptionStatusWebhookCallbackVerificationFailed,
+		eventsub.SubscriptionStatusNotificationFailuresExceeded,
+		eventsub.SubscriptionStatusWebsocketDisconnected,
+		eventsub.SubscriptionStatusWebsocketFailedPingPong,
+		eventsub.SubscriptionStatusWebsocketReceivedInboundTraffic,
+		eventsub.SubscriptionStatusWebsocketConnectionUnused,
+		eventsub.SubscriptionStatusWebsocketInternalError,
+		eventsub.SubscriptionStatusWebsocketNetworkTimeout,
+		eventsub.SubscriptionStatusWebsocketNetworkError,
+		eventsub.SubscriptionStatusWebsocketFailedToReconnect:
+		return true
+	default:
+		return false
+	}
+}
+
+// subscriptionKey returns key that identifies subscription by its type, version, condition and transport destination.
+func subscriptionKey(
+	eventType eventsub.EventType,
+	version string,
+	condition eventsub.AnyCondition,
+	transport eventsub.AnyTransport,
+) string {
+	fields := make([]string, 0, len(condition))
+
+	for field, value := range condition {
+		// Twitch may return optional condition fields as empty strings, so they are ignored.
+		if value != "" {
+			fields = append(fields, field+"="+value)
+		}
+	}
+
+	slices.Sort(fields)
+
+	return strings.Join([]string{
+		eventType.String(),
+		version,
+		strings.Join(fields, "&"),
+		transport.Method,
+		transport.Callback,
+		transport.SessionId,
+		transport.ConduitId,
+	}, "|")
+}
+
+// convertJSON converts value to the target through its JSON representation.
+func convertJSON(value, target any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err = json.Unmarshal(payload, target); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return nil
+}
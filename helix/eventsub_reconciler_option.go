@@ -0,0 +1,29 @@
+package helix
+
+// ReconcilerOption is an optional setting for Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// ReconcilerWithDryRun specifies that Reconciler only plans actions without applying them.
+func ReconcilerWithDryRun() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.dryRun = true
+	}
+}
+
+// ReconcilerWithFilter sets filter of existing subscriptions that are reconciled (e.g. by UserId of the broadcaster).
+// Subscriptions outside the filter are never deleted.
+//
+// Default value is no filter, so all subscriptions of the client are reconciled.
+func ReconcilerWithFilter(filter GetEventSubSubscriptionsParams) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.filter = filter
+	}
+}
+
+// ReconcilerWithKeepExtras specifies that Reconciler doesn't delete existing subscriptions that are not desired. Stale
+// and duplicated desired subscriptions are still deleted.
+func ReconcilerWithKeepExtras() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.keepExtras = true
+	}
+}
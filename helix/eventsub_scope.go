@@ -0,0 +1,273 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/twirapp/twitchy/eventsub"
+)
+
+// ErrUnknownSubscriptionType indicates that there is no scope requirement registered for the subscription type and
+// version.
+var ErrUnknownSubscriptionType = errors.New("unknown subscription type")
+
+// Condition fields of the user who must authorize the access token.
+const (
+	ConditionBroadcasterUserId = "broadcaster_user_id"
+	ConditionModeratorUserId   = "moderator_user_id"
+	ConditionUserId            = "user_id"
+)
+
+// ScopeRequirement is an authorization requirement of the eventsub subscription type.
+type ScopeRequirement struct {
+	// AnyOf is a list of scope sets where the token must have all scopes of at least one set. It's empty if the
+	// subscription type doesn't require any scopes.
+	AnyOf [][]AuthorizationScope
+	// ConditionUserField is a condition field that contains id of the user who must authorize the token
+	// (e.g. ConditionModeratorUserId). It's empty if the subscription type doesn't require user authorization.
+	ConditionUserField string
+}
+
+type scopeRequirementKey struct {
+	eventType eventsub.EventType
+	version   string
+}
+
+// requireAll returns requirement of all the scopes authorized by the user in the condition field.
+func requireAll(userField string, scopes ...AuthorizationScope) ScopeRequirement {
+	return ScopeRequirement{
+		AnyOf:              [][]AuthorizationScope{scopes},
+		ConditionUserField: userField,
+	}
+}
+
+// requireAny returns requirement of any of the scopes authorized by the user in the condition field.
+func requireAny(userField string, scopes ...AuthorizationScope) ScopeRequirement {
+	anyOf := make([][]AuthorizationScope, 0, len(scopes))
+	for _, scope := range scopes {
+		anyOf = append(anyOf, []AuthorizationScope{scope})
+	}
+
+	return ScopeRequirement{
+		AnyOf:              anyOf,
+		ConditionUserField: userField,
+	}
+}
+
+// scopeRequirements is a registry of scope requirements of the eventsub subscription types.
+//
+// Reference: https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types.
+var scopeRequirements = map[scopeRequirementKey]ScopeRequirement{
+	{eventsub.EventTypeAutomodMessageHold, "1"}:   requireAll(ConditionModeratorUserId, ScopeModeratorManageAutoMod),
+	{eventsub.EventTypeAutomodMessageHold, "2"}:   requireAll(ConditionModeratorUserId, ScopeModeratorManageAutoMod),
+	{eventsub.EventTypeAutomodMessageUpdate, "1"}: requireAll(ConditionModeratorUserId, ScopeModeratorManageAutoMod),
+	{eventsub.EventTypeAutomodMessageUpdate, "2"}: requireAll(ConditionModeratorUserId, ScopeModeratorManageAutoMod),
+	{eventsub.EventTypeAutomodSettingsUpdate, "1"}: requireAny(
+		ConditionModeratorUserId,
+		ScopeModeratorReadAutoModSettings,
+		ScopeModeratorManageAutoModSettings,
+	),
+	{eventsub.EventTypeAutomodTermsUpdate, "1"}:           requireAll(ConditionModeratorUserId, ScopeModeratorManageAutoMod),
+	{eventsub.EventTypeChannelBitsUse, "1"}:               requireAll(ConditionBroadcasterUserId, ScopeBitsRead),
+	{eventsub.EventTypeChannelUpdate, "2"}:                {},
+	{eventsub.EventTypeChannelFollow, "2"}:                requireAll(ConditionModeratorUserId, ScopeModeratorReadFollowers),
+	{eventsub.EventTypeChannelAdBreakBegin, "1"}:          requireAll(ConditionBroadcasterUserId, ScopeChannelReadAds),
+	{eventsub.EventTypeChannelChatClear, "1"}:             requireAll(ConditionUserId, ScopeUserReadChat),
+	{eventsub.EventTypeChannelChatClearUserMessages, "1"}: requireAll(ConditionUserId, ScopeUserReadChat),
+	{eventsub.EventTypeChannelChatMessage, "1"}:           requireAll(ConditionUserId, ScopeUserReadChat),
+	{eventsub.EventTypeChannelChatNotification, "1"}:      requireAll(ConditionUserId, ScopeUserReadChat),
+	{eventsub.EventTypeChannelMessageDelete, "1"}:         requireAll(ConditionUserId, ScopeUserReadChat),
+	{eventsub.EventTypeConduitShardDisabled, "1"}:         {},
+	{eventsub.EventTypeChannelBan, "1"}:                   requireAll(ConditionBroadcasterUserId, ScopeChannelModerate),
+	{eventsub.EventTypeChannelUnban, "1"}:                 requireAll(ConditionBroadcasterUserId, ScopeChannelModerate),
+	{eventsub.EventTypeChannelModeratorAdd, "1"}:          requireAll(ConditionBroadcasterUserId, ScopeModerationRead),
+	{eventsub.EventTypeChannelModeratorRemove, "1"}:       requireAll(ConditionBroadcasterUserId, ScopeModerationRead),
+	{eventsub.EventTypeChannelPollBegin, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadPolls,
+		ScopeChannelManagePolls,
+	),
+	{eventsub.EventTypeChannelPollProgress, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadPolls,
+		ScopeChannelManagePolls,
+	),
+	{eventsub.EventTypeChannelPollEnd, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadPolls,
+		ScopeChannelManagePolls,
+	),
+	{eventsub.EventTypeChannelPredictionBegin, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadPredictions,
+		ScopeChannelManagePredictions,
+	),
+	{eventsub.EventTypeChannelPredictionProgress, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadPredictions,
+		ScopeChannelManagePredictions,
+	),
+	{eventsub.EventTypeChannelPredictionLock, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadPredictions,
+		ScopeChannelManagePredictions,
+	),
+	{eventsub.EventTypeChannelPredictionEnd, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadPredictions,
+		ScopeChannelManagePredictions,
+	),
+	{eventsub.EventTypeChannelRaid, "1"}: {},
+	{eventsub.EventTypeChannelPointsCustomRewardRedemptionAdd, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadRedemptions,
+		ScopeChannelManageRedemptions,
+	),
+	{eventsub.EventTypeChannelPointsCustomRewardRedemptionUpdate, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadRedemptions,
+		ScopeChannelManageRedemptions,
+	),
+	{eventsub.EventTypeChannelPointsAutomaticRewardRedemptionAdd, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadRedemptions,
+		ScopeChannelManageRedemptions,
+	),
+	{eventsub.EventTypeChannelPointsAutomaticRewardRedemptionAdd, "2"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadRedemptions,
+		ScopeChannelManageRedemptions,
+	),
+	{eventsub.EventTypeUserAuthorizationRevoke, "1"}: {},
+	{eventsub.EventTypeChannelPointsRewardAdd, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadRedemptions,
+		ScopeChannelManageRedemptions,
+	),
+	{eventsub.EventTypeChannelPointsRewardUpdate, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadRedemptions,
+		ScopeChannelManageRedemptions,
+	),
+	{eventsub.EventTypeChannelPointsRewardRemove, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadRedemptions,
+		ScopeChannelManageRedemptions,
+	),
+	{eventsub.EventTypeStreamOffline, "1"}: {},
+	{eventsub.EventTypeStreamOnline, "1"}:  {},
+	{eventsub.EventTypeChannelSubscribe, "1"}: requireAll(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadSubscriptions,
+	),
+	{eventsub.EventTypeChannelSubscriptionEnd, "1"}: requireAll(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadSubscriptions,
+	),
+	{eventsub.EventTypeChannelSubscriptionMessage, "1"}: requireAll(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadSubscriptions,
+	),
+	{eventsub.EventTypeChannelSubscriptionGift, "1"}: requireAll(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadSubscriptions,
+	),
+	{eventsub.EventTypeChannelUnbanRequestCreate, "1"}: requireAny(
+		ConditionModeratorUserId,
+		ScopeModeratorReadUnbanRequests,
+		ScopeModeratorManageUnbanRequests,
+	),
+	{eventsub.EventTypeChannelUnbanRequestResolve, "1"}: requireAny(
+		ConditionModeratorUserId,
+		ScopeModeratorReadUnbanRequests,
+		ScopeModeratorManageUnbanRequests,
+	),
+	{eventsub.EventTypeUserUpdate, "1"}: {},
+	{eventsub.EventTypeChannelVipAdd, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadVIPs,
+		ScopeChannelManageVIPs,
+	),
+	{eventsub.EventTypeChannelVipRemove, "1"}: requireAny(
+		ConditionBroadcasterUserId,
+		ScopeChannelReadVIPs,
+		ScopeChannelManageVIPs,
+	),
+}
+
+// EventSubScopeRequirement returns scope requirement of the eventsub subscription type and version, or false if the
+// subscription type and version are unknown.
+func EventSubScopeRequirement(eventType eventsub.EventType, version string) (ScopeRequirement, bool) {
+	requirement, ok := scopeRequirements[scopeRequirementKey{eventType, version}]
+	return requirement, ok
+}
+
+// MissingScopesError is an error that explains which scopes are missing to create eventsub subscription.
+type MissingScopesError struct {
+	EventType eventsub.EventType
+	Version   string
+	// Missing is a list of scope sets where granting all scopes of any set satisfies the requirement.
+	Missing [][]AuthorizationScope
+	// ConditionUserField is a condition field that contains id of the user who must grant the scopes.
+	ConditionUserField string
+}
+
+func (e *MissingScopesError) Error() string {
+	options := make([]string, 0, len(e.Missing))
+	for _, scopes := range e.Missing {
+		options = append(options, joinScopes(scopes))
+	}
+
+	user := "the user"
+	if e.ConditionUserField != "" {
+		user += " in " + e.ConditionUserField
+	}
+
+	return fmt.Sprintf(
+		"%s v%s requires token of %s with scopes: %s",
+		e.EventType,
+		e.Version,
+		user,
+		strings.Join(options, " or "),
+	)
+}
+
+// CheckScopes checks that granted scopes satisfy the requirement of the eventsub subscription type and version.
+// MissingScopesError is returned if they don't, and ErrUnknownSubscriptionType is returned if the subscription type
+// and version are unknown.
+func CheckScopes(granted Scopes, eventType eventsub.EventType, version string) error {
+	requirement, ok := EventSubScopeRequirement(eventType, version)
+	if !ok {
+		return fmt.Errorf("%s v%s: %w", eventType, version, ErrUnknownSubscriptionType)
+	}
+
+	if len(requirement.AnyOf) == 0 {
+		return nil
+	}
+
+	missing := make([][]AuthorizationScope, 0, len(requirement.AnyOf))
+
+	for _, scopes := range requirement.AnyOf {
+		var missingScopes []AuthorizationScope
+
+		for _, scope := range scopes {
+			if !granted.Contains(scope) {
+				missingScopes = append(missingScopes, scope)
+			}
+		}
+
+		if len(missingScopes) == 0 {
+			return nil
+		}
+
+		missing = append(missing, missingScopes)
+	}
+
+	return &MissingScopesError{
+		EventType:          eventType,
+		Version:            version,
+		Missing:            missing,
+		ConditionUserField: requirement.ConditionUserField,
+	}
+}
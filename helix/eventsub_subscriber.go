@@ -0,0 +1,60 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/twirapp/twitchy/eventsub"
+)
+
+// websocketSubscriber is an eventsub.Subscriber that creates websocket subscriptions with Helix.
+type websocketSubscriber struct {
+	helix *Helix
+}
+
+var _ eventsub.Subscriber = websocketSubscriber{}
+
+// WebsocketSubscriber returns eventsub.Subscriber that creates websocket subscriptions with Helix, so it can be used
+// with eventsub.WebsocketWithSubscriber. Helix must be authorized with user access token as Twitch requires for
+// websocket subscriptions.
+func WebsocketSubscriber(helix *Helix) eventsub.Subscriber {
+	return websocketSubscriber{
+		helix: helix,
+	}
+}
+
+func (ws websocketSubscriber) Subscribe(
+	ctx context.Context,
+	subscription eventsub.WebsocketSubscription,
+	transport eventsub.WebsocketTransport,
+) (int, error) {
+	var condition eventsub.AnyCondition
+
+	if err := convertJSON(subscription.Condition, &condition); err != nil {
+		return 0, fmt.Errorf("convert condition: %w", err)
+	}
+
+	created, _, err := CreateEventSubSubscription(ctx, ws.helix, CreateEventSubSubscriptionRequest[
+		eventsub.AnyCondition,
+		eventsub.WebsocketTransport,
+	]{
+		Type:      subscription.Type,
+		Version:   subscription.Version,
+		Condition: condition,
+		Transport: transport,
+	})
+	if err != nil {
+		var apiError *APIError
+
+		// Subscription already exists for the session, so there is nothing to do.
+		if errors.As(err, &apiError) && apiError.Status == http.StatusConflict {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return created.Cost, nil
+}
@@ -0,0 +1,126 @@
+package helix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/twirapp/twitchy/internal/json"
+)
+
+const helixURL = "https://api.twitch.tv/helix"
+
+// ErrNoTokenSource indicates that Helix has no token source to authorize requests with.
+var ErrNoTokenSource = errors.New("token source is not set")
+
+// Helix is a Twitch Helix API client.
+//
+// Reference: https://dev.twitch.tv/docs/api.
+type Helix struct {
+	client      *http.Client
+	baseURL     string
+	clientId    string
+	tokenSource TokenSource
+}
+
+func New(clientId string, options ...Option) *Helix {
+	helix := &Helix{
+		client:   http.DefaultClient,
+		baseURL:  helixURL,
+		clientId: clientId,
+	}
+
+	for _, option := range options {
+		option(helix)
+	}
+
+	return helix
+}
+
+// request is a single request to the Helix API.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+}
+
+// do sends request to the Helix API and decodes JSON response body to the result if it's not nil.
+//
+// If Helix responds with non-successful status code, APIError will be returned.
+func (h *Helix) do(ctx context.Context, req request, result any) error {
+	var body io.Reader
+
+	if req.body != nil {
+		payload, err := json.Marshal(req.body)
+		if err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+
+		body = bytes.NewReader(payload)
+	}
+
+	requestURL := h.baseURL + req.path
+	if len(req.query) > 0 {
+		requestURL += "?" + req.query.Encode()
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, req.method, requestURL, body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	if h.tokenSource == nil {
+		return ErrNoTokenSource
+	}
+
+	token, err := h.tokenSource.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	httpRequest.Header.Set("Client-Id", h.clientId)
+	httpRequest.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	if req.body != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+
+	return send(h.client, httpRequest, result)
+}
+
+// send sends HTTP request and decodes JSON response body to the result if it's not nil.
+//
+// If server responds with non-successful status code, APIError will be returned.
+func send(client *http.Client, httpRequest *http.Request, result any) error {
+	response, err := client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return newAPIError(response.StatusCode, responseBody)
+	}
+
+	if result == nil || len(responseBody) == 0 {
+		return nil
+	}
+
+	if err = json.Unmarshal(responseBody, result); err != nil {
+		return fmt.Errorf("unmarshal response body: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package helix
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const oauthURL = "https://id.twitch.tv/oauth2"
+
+// ErrInvalidToken indicates that access token is invalid (e.g. expired or revoked).
+var ErrInvalidToken = errors.New("invalid access token")
+
+// OAuth is a Twitch OAuth client that is used to obtain access tokens.
+//
+// Reference: https://dev.twitch.tv/docs/authentication.
+type OAuth struct {
+	client       *http.Client
+	baseURL      string
+	clientId     string
+	clientSecret string
+}
+
+func NewOAuth(clientId, clientSecret string, options ...OAuthOption) *OAuth {
+	oauth := &OAuth{
+		client:       http.DefaultClient,
+		baseURL:      oauthURL,
+		clientId:     clientId,
+		clientSecret: clientSecret,
+	}
+
+	for _, option := range options {
+		option(oauth)
+	}
+
+	return oauth
+}
+
+// tokenResponse is a response of the token endpoint.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        Scopes `json:"scope"`
+	TokenType    string `json:"token_type"`
+}
+
+func (tr tokenResponse) token() Token {
+	token := Token{
+		AccessToken:  tr.AccessToken,
+		RefreshToken: tr.RefreshToken,
+		Scopes:       tr.Scope,
+	}
+
+	if tr.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+
+	return token
+}
+
+// AppToken obtains new app access token with client credentials grant flow.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/getting-tokens-oauth/#client-credentials-grant-flow.
+func (o *OAuth) AppToken(ctx context.Context) (Token, error) {
+	return o.token(ctx, url.Values{
+		"client_id":     {o.clientId},
+		"client_secret": {o.clientSecret},
+		"grant_type":    {"client_credentials"},
+	})
+}
+
+// AuthorizeParams is a set of parameters of the authorization URL.
+type AuthorizeParams struct {
+	// RedirectURI is a URI where user is redirected after authorization, it must match one of the redirect URIs
+	// registered for the app.
+	RedirectURI string
+	// Scopes is a set of scopes that app requests from the user (see NewScopes).
+	Scopes Scopes
+	// State is an opaque value that is returned with redirect to protect against CSRF attacks (see NewState).
+	State string
+	// Nonce is an opaque value that is included into the ID token to protect against replay attacks if openid scope
+	// is requested.
+	Nonce string
+	// ForceVerify forces user to re-authorize app even if user has already authorized it.
+	ForceVerify bool
+}
+
+// AuthorizeURL returns URL of the authorization page where user should be redirected to authorize the app with
+// authorization code grant flow.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/getting-tokens-oauth/#authorization-code-grant-flow.
+func (o *OAuth) AuthorizeURL(params AuthorizeParams) string {
+	query := url.Values{
+		"client_id":     {o.clientId},
+		"redirect_uri":  {params.RedirectURI},
+		"response_type": {"code"},
+		"scope":         {params.Scopes.String()},
+	}
+
+	if params.State != "" {
+		query.Set("state", params.State)
+	}
+	if params.Nonce != "" {
+		query.Set("nonce", params.Nonce)
+	}
+	if params.ForceVerify {
+		query.Set("force_verify", "true")
+	}
+
+	return o.baseURL + "/authorize?" + query.Encode()
+}
+
+// ExchangeCode exchanges authorization code received with redirect for user access token.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/getting-tokens-oauth/#authorization-code-grant-flow.
+func (o *OAuth) ExchangeCode(ctx context.Context, code, redirectURI string) (Token, error) {
+	return o.token(ctx, url.Values{
+		"client_id":     {o.clientId},
+		"client_secret": {o.clientSecret},
+		"code":          {code},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {redirectURI},
+	})
+}
+
+// RefreshToken obtains new user access token with the refresh token. Twitch may rotate refresh token, so the returned
+// token must replace the old one in your storage. Client secret is omitted if it's empty, as public clients have none.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/refresh-tokens.
+func (o *OAuth) RefreshToken(ctx context.Context, refreshToken string) (Token, error) {
+	form := url.Values{
+		"client_id":     {o.clientId},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}
+
+	// Public clients (e.g. ones that use device code grant flow) have no client secret.
+	if o.clientSecret != "" {
+		form.Set("client_secret", o.clientSecret)
+	}
+
+	return o.token(ctx, form)
+}
+
+// TokenValidation is an information about the validated access token.
+type TokenValidation struct {
+	ClientId string `json:"client_id"`
+	Login    string `json:"login"`
+	UserId   string `json:"user_id"`
+	Scopes   Scopes `json:"scopes"`
+	// ExpiresIn is a number of seconds until the token expires.
+	ExpiresIn int `json:"expires_in"`
+}
+
+// ValidateToken validates access token and returns information about it. ErrInvalidToken is returned if token is no
+// longer valid (e.g. it was revoked by the user).
+//
+// Twitch requires apps to validate user access tokens on startup and hourly after that (see
+// UserTokenSource.StartValidationWorker).
+//
+// Reference: https://dev.twitch.tv/docs/authentication/validate-tokens.
+func (o *OAuth) ValidateToken(ctx context.Context, accessToken string) (TokenValidation, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+"/validate", nil)
+	if err != nil {
+		return TokenValidation{}, fmt.Errorf("create request: %w", err)
+	}
+
+	httpRequest.Header.Set("Authorization", "OAuth "+accessToken)
+
+	var validation TokenValidation
+
+	if err = send(o.client, httpRequest, &validation); err != nil {
+		var apiError *APIError
+
+		if errors.As(err, &apiError) && apiError.Status == http.StatusUnauthorized {
+			return TokenValidation{}, ErrInvalidToken
+		}
+
+		return TokenValidation{}, err
+	}
+
+	return validation, nil
+}
+
+// RevokeToken revokes access token, so it can't be used anymore.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/revoke-tokens.
+func (o *OAuth) RevokeToken(ctx context.Context, accessToken string) error {
+	return o.postForm(ctx, "/revoke", url.Values{
+		"client_id": {o.clientId},
+		"token":     {accessToken},
+	}, nil)
+}
+
+// NewState returns a random opaque value that is suitable for AuthorizeParams State and Nonce.
+func NewState() (string, error) {
+	state := make([]byte, 16)
+
+	if _, err := rand.Read(state); err != nil {
+		return "", fmt.Errorf("read random bytes: %w", err)
+	}
+
+	return hex.EncodeToString(state), nil
+}
+
+// token requests the token endpoint with provided form and returns obtained token.
+func (o *OAuth) token(ctx context.Context, form url.Values) (Token, error) {
+	var response tokenResponse
+
+	if err := o.postForm(ctx, "/token", form, &response); err != nil {
+		return Token{}, err
+	}
+
+	return response.token(), nil
+}
+
+// postForm sends form to the OAuth endpoint and decodes JSON response body to the result if it's not nil.
+func (o *OAuth) postForm(ctx context.Context, path string, form url.Values, result any) error {
+	httpRequest, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		o.baseURL+path,
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return send(o.client, httpRequest, result)
+}
+
+// joinScopes joins scopes to the space-separated list as expected by the OAuth server.
+func joinScopes(scopes []AuthorizationScope) string {
+	rawScopes := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		rawScopes = append(rawScopes, string(scope))
+	}
+
+	return strings.Join(rawScopes, " ")
+}
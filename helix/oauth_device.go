@@ -0,0 +1,116 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// deviceGrantType is a grant type of the device code grant flow.
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// deviceDefaultInterval is a polling interval used if the OAuth server doesn't specify it.
+	deviceDefaultInterval = 5 * time.Second
+	// deviceSlowDownDelta is a value the polling interval is increased by on each slow_down response.
+	deviceSlowDownDelta = 5 * time.Second
+)
+
+// ErrDeviceCodeExpired indicates that user has not authorized the app before the device code expired.
+var ErrDeviceCodeExpired = errors.New("device code expired")
+
+// DeviceAuthorization is a device code that user must enter on the verification page to authorize the app.
+type DeviceAuthorization struct {
+	// DeviceCode is a code that is used to poll the token, it must not be shown to the user.
+	DeviceCode string `json:"device_code"`
+	// UserCode is a code that user enters on the verification page.
+	UserCode string `json:"user_code"`
+	// VerificationURI is a URI of the verification page that must be presented to the user. The user code is already
+	// included in it.
+	VerificationURI string `json:"verification_uri"`
+	// ExpiresIn is a number of seconds until the device code expires.
+	ExpiresIn int `json:"expires_in"`
+	// Interval is a minimal number of seconds between token polling requests.
+	Interval int `json:"interval"`
+	// Scopes is a set of requested scopes, it's required to poll the token.
+	Scopes Scopes `json:"-"`
+}
+
+// RequestDeviceCode starts device code grant flow for the app without browser access (e.g. bot running on a server).
+// The returned VerificationURI must be presented to the user before polling the token with PollDeviceToken.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/getting-tokens-oauth/#device-code-grant-flow.
+func (o *OAuth) RequestDeviceCode(ctx context.Context, scopes Scopes) (DeviceAuthorization, error) {
+	var authorization DeviceAuthorization
+
+	err := o.postForm(ctx, "/device", url.Values{
+		"client_id": {o.clientId},
+		"scopes":    {scopes.String()},
+	}, &authorization)
+	if err != nil {
+		return DeviceAuthorization{}, err
+	}
+
+	authorization.Scopes = scopes
+
+	return authorization, nil
+}
+
+// PollDeviceToken blocks on polling the token endpoint until user authorizes the app, device code expires or context
+// is canceled. It honors the polling interval and slows down when the OAuth server asks to do so.
+//
+// The returned token is a refreshable user access token that can be used with UserTokenSource.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/getting-tokens-oauth/#device-code-grant-flow.
+func (o *OAuth) PollDeviceToken(ctx context.Context, authorization DeviceAuthorization) (Token, error) {
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = deviceDefaultInterval
+	}
+
+	expiresAt := time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+
+	form := url.Values{
+		"client_id":   {o.clientId},
+		"device_code": {authorization.DeviceCode},
+		"grant_type":  {deviceGrantType},
+		"scopes":      {authorization.Scopes.String()},
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return Token{}, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if time.Now().After(expiresAt) {
+			return Token{}, ErrDeviceCodeExpired
+		}
+
+		token, err := o.token(ctx, form)
+		if err == nil {
+			return token, nil
+		}
+
+		var apiError *APIError
+
+		if !errors.As(err, &apiError) || apiError.Status != http.StatusBadRequest {
+			return Token{}, fmt.Errorf("poll token: %w", err)
+		}
+
+		switch apiError.Message {
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += deviceSlowDownDelta
+		case "invalid device code":
+			return Token{}, ErrDeviceCodeExpired
+		default:
+			return Token{}, fmt.Errorf("poll token: %w", err)
+		}
+	}
+}
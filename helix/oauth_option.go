@@ -0,0 +1,27 @@
+package helix
+
+import (
+	"net/http"
+)
+
+// OAuthOption is an optional setting for OAuth.
+type OAuthOption func(*OAuth)
+
+// OAuthWithClient sets HTTP client that will be used to send requests to the OAuth server.
+//
+// Default value is http.DefaultClient.
+func OAuthWithClient(client *http.Client) OAuthOption {
+	return func(o *OAuth) {
+		o.client = client
+	}
+}
+
+// OAuthWithBaseURL sets the URL that will be used as the base URL of the OAuth server. It's helpful when you want to
+// test your client with custom server (e.g. httptest.Server or Twitch CLI mock API).
+//
+// Default value is "https://id.twitch.tv/oauth2".
+func OAuthWithBaseURL(baseURL string) OAuthOption {
+	return func(o *OAuth) {
+		o.baseURL = baseURL
+	}
+}
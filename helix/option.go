@@ -0,0 +1,43 @@
+package helix
+
+import (
+	"net/http"
+)
+
+// Option is an optional setting for Helix.
+type Option func(*Helix)
+
+// WithClient sets HTTP client that will be used to send requests to the Helix API.
+//
+// Default value is http.DefaultClient.
+func WithClient(client *http.Client) Option {
+	return func(h *Helix) {
+		h.client = client
+	}
+}
+
+// WithBaseURL sets the URL that will be used as the base URL of the Helix API. It's helpful when you want to test your
+// client with custom server (e.g. httptest.Server or Twitch CLI mock API).
+//
+// Default value is "https://api.twitch.tv/helix".
+func WithBaseURL(baseURL string) Option {
+	return func(h *Helix) {
+		h.baseURL = baseURL
+	}
+}
+
+// WithAccessToken sets static access token that will be used to authorize requests to the Helix API. It's a shortcut
+// for WithTokenSource with StaticTokenSource.
+func WithAccessToken(accessToken string) Option {
+	return func(h *Helix) {
+		h.tokenSource = StaticTokenSource(Token{AccessToken: accessToken})
+	}
+}
+
+// WithTokenSource sets token source that will be used to get access token for each request to the Helix API
+// (e.g. AppTokenSource).
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(h *Helix) {
+		h.tokenSource = tokenSource
+	}
+}
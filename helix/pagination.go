@@ -0,0 +1,118 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrInvalidPageSize indicates that page size set by PaginateWithPageSize is out of range supported by Twitch API.
+var ErrInvalidPageSize = errors.New("page size must be between 1 and 100")
+
+// maxPageSize is a maximum page size of list endpoints.
+const maxPageSize = 100
+
+// Pagination is a pagination information of list endpoints.
+//
+// Reference: https://dev.twitch.tv/docs/api/guide/#pagination.
+type Pagination struct {
+	// Cursor is a cursor used to get the next page of results, it's empty if there are no more pages.
+	Cursor string `json:"cursor"`
+}
+
+// PageFetcher fetches a page of items of the list endpoint after the cursor and returns them with the cursor of the
+// next page. The first is a requested page size, it's zero if page size is not set and should be ignored by endpoints
+// that don't support it.
+type PageFetcher[T any] func(ctx context.Context, cursor string, first int) ([]T, string, error)
+
+// PaginateOption is an optional setting for Paginate.
+type PaginateOption func(*paginateOption)
+
+type paginateOption struct {
+	pageSize int
+	maxItems int
+}
+
+// PaginateWithPageSize sets number of items requested per page for endpoints that support it. Page size must be
+// between 1 and 100, otherwise Paginate yields ErrInvalidPageSize.
+//
+// Default value is endpoint's default page size.
+func PaginateWithPageSize(pageSize int) PaginateOption {
+	return func(po *paginateOption) {
+		po.pageSize = pageSize
+	}
+}
+
+// PaginateWithMaxItems sets maximum number of items to iterate over, no more pages are fetched after it's reached.
+//
+// Default value is 0 (unlimited).
+func PaginateWithMaxItems(maxItems int) PaginateOption {
+	return func(po *paginateOption) {
+		po.maxItems = maxItems
+	}
+}
+
+// Paginate returns iterator over items of all pages of the list endpoint. Pages are fetched lazily while the
+// iteration continues. Iteration stops after the first error, which is yielded with zero item, or when context is
+// canceled.
+//
+// Usage example:
+//
+//	for subscription, err := range api.IterEventSubSubscriptions(ctx, params) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Paginate[T any](ctx context.Context, fetch PageFetcher[T], options ...PaginateOption) iter.Seq2[T, error] {
+	var opt paginateOption
+
+	for _, option := range options {
+		option(&opt)
+	}
+
+	return func(yield func(T, error) bool) {
+		var (
+			zero   T
+			cursor string
+			count  int
+		)
+
+		if opt.pageSize != 0 && (opt.pageSize < 1 || opt.pageSize > maxPageSize) {
+			yield(zero, fmt.Errorf("%w: %d", ErrInvalidPageSize, opt.pageSize))
+			return
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, nextCursor, err := fetch(ctx, cursor, opt.pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if opt.maxItems > 0 && count >= opt.maxItems {
+					return
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+
+				count++
+			}
+
+			if nextCursor == "" || len(items) == 0 || (opt.maxItems > 0 && count >= opt.maxItems) {
+				return
+			}
+
+			cursor = nextCursor
+		}
+	}
+}
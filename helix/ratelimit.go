@@ -0,0 +1,286 @@
+package helix
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// bucketIdleTimeout is a time after the bucket reset when bucket that is not used by requests is evicted, so buckets of
+// rotated access tokens don't pile up.
+const bucketIdleTimeout = 1 * time.Minute
+
+// RateLimit is a state of the rate limit bucket reported by Helix in the response headers.
+//
+// Reference: https://dev.twitch.tv/docs/api/guide/#twitch-rate-limits.
+type RateLimit struct {
+	// Limit is a rate at which points are added to the bucket.
+	Limit int
+	// Remaining is a number of points remaining in the bucket.
+	Remaining int
+	// Reset is a time when the bucket is reset to full.
+	Reset time.Time
+}
+
+// RateLimitTransport is an http.RoundTripper that tracks Helix rate limits per access token. Requests are queued
+// instead of failing when the bucket is empty, and requests rejected with 429 status code are retried after the
+// bucket reset. It's safe for concurrent use.
+//
+// Usage example:
+//
+//	client := &http.Client{Transport: helix.NewRateLimitTransport(nil)}
+//	api := helix.New(clientId, helix.WithClient(client), helix.WithTokenSource(tokenSource))
+type RateLimitTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+
+	// buckets maps hash of the client id and access token to the bucket, so tokens are not kept in memory.
+	buckets   map[[sha256.Size]byte]*rateLimitBucket
+	lastEvict time.Time
+	mu        sync.Mutex
+
+	onUpdate func(RateLimit)
+	onWait   func(time.Duration)
+	onRetry  func(attempt int)
+}
+
+var _ http.RoundTripper = (*RateLimitTransport)(nil)
+
+// NewRateLimitTransport returns RateLimitTransport that sends requests with base transport, or with
+// http.DefaultTransport if base is nil.
+func NewRateLimitTransport(base http.RoundTripper, options ...RateLimitOption) *RateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	transport := &RateLimitTransport{
+		base:       base,
+		maxRetries: 3,
+		buckets:    make(map[[sha256.Size]byte]*rateLimitBucket),
+	}
+
+	for _, option := range options {
+		option(transport)
+	}
+
+	return transport
+}
+
+func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bucket := rlt.bucket(rateLimitBucketKey(req))
+
+	for attempt := 0; ; attempt++ {
+		if err := rlt.wait(req.Context(), bucket); err != nil {
+			// Body of the retried request has already been closed by the base transport.
+			if attempt == 0 && req.Body != nil {
+				_ = req.Body.Close()
+			}
+
+			return nil, err
+		}
+
+		attemptRequest := req
+		if attempt > 0 {
+			var err error
+
+			if attemptRequest, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := rlt.base.RoundTrip(attemptRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		limited := response.StatusCode == http.StatusTooManyRequests
+
+		rateLimit, ok := parseRateLimit(response.Header)
+		if ok {
+			bucket.update(rateLimit, limited)
+
+			if rlt.onUpdate != nil {
+				rlt.onUpdate(rateLimit)
+			}
+		} else if limited {
+			// Helix always sends rate limit headers, but we still should not retry immediately without them.
+			bucket.update(RateLimit{Limit: 1, Reset: time.Now().Add(time.Second)}, limited)
+		}
+
+		if !limited || attempt >= rlt.maxRetries {
+			return response, nil
+		}
+
+		_ = response.Body.Close()
+
+		if rlt.onRetry != nil {
+			rlt.onRetry(attempt + 1)
+		}
+	}
+}
+
+// wait blocks until the bucket has remaining points and reserves one of them.
+func (rlt *RateLimitTransport) wait(ctx context.Context, bucket *rateLimitBucket) error {
+	for {
+		delay := bucket.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		if rlt.onWait != nil {
+			rlt.onWait(delay)
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// bucket returns bucket of the access token, creating it if it doesn't exist yet. Idle buckets are evicted at most once
+// per bucketIdleTimeout.
+func (rlt *RateLimitTransport) bucket(key [sha256.Size]byte) *rateLimitBucket {
+	rlt.mu.Lock()
+	defer rlt.mu.Unlock()
+
+	now := time.Now()
+
+	if now.Sub(rlt.lastEvict) >= bucketIdleTimeout {
+		rlt.lastEvict = now
+
+		for bucketKey, bucket := range rlt.buckets {
+			if bucket.isIdle(now) {
+				delete(rlt.buckets, bucketKey)
+			}
+		}
+	}
+
+	bucket, ok := rlt.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{}
+		rlt.buckets[key] = bucket
+	}
+
+	bucket.touch(now)
+
+	return bucket
+}
+
+// rateLimitBucketKey returns key of the request bucket. Helix tracks rate limits per client id and user for user access
+// tokens, and per client id for app access tokens.
+func rateLimitBucketKey(req *http.Request) [sha256.Size]byte {
+	return sha256.Sum256([]byte(req.Header.Get("Client-Id") + "\x00" + req.Header.Get("Authorization")))
+}
+
+// rateLimitBucket is a local copy of the Helix rate limit bucket of the access token.
+type rateLimitBucket struct {
+	RateLimit
+	lastUsed time.Time
+	mu       sync.Mutex
+}
+
+func (b *rateLimitBucket) touch(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastUsed = now
+}
+
+// isIdle returns true if bucket has not been used for bucketIdleTimeout and it has been reset since then, so evicting it
+// doesn't lose any rate limit state.
+func (b *rateLimitBucket) isIdle(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return now.Sub(b.lastUsed) >= bucketIdleTimeout && !now.Before(b.Reset)
+}
+
+// reserve reserves a point in the bucket and returns zero, or returns delay until the bucket reset if it's empty.
+func (b *rateLimitBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Limit is unknown until the first response, so we let requests through.
+	if b.Limit == 0 {
+		return 0
+	}
+
+	now := time.Now()
+
+	if !now.Before(b.Reset) {
+		b.Remaining = b.Limit
+	}
+
+	if b.Remaining > 0 {
+		b.Remaining--
+		return 0
+	}
+
+	return b.Reset.Sub(now)
+}
+
+// update updates bucket with the rate limit reported by Helix. Bucket is emptied if the request was rate limited.
+func (b *rateLimitBucket) update(rateLimit RateLimit, limited bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.RateLimit = rateLimit
+
+	if limited {
+		b.Remaining = 0
+	}
+}
+
+// parseRateLimit parses rate limit headers of the Helix response.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("Ratelimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	remaining, err := strconv.Atoi(header.Get("Ratelimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	reset, err := strconv.ParseInt(header.Get("Ratelimit-Reset"), 10, 64)
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}, true
+}
+
+// rewindRequest returns copy of the request with a fresh body to send it again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req.Clone(req.Context()), nil
+	}
+
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("retry request: body can't be rewound")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("retry request: get body: %w", err)
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+
+	return clone, nil
+}
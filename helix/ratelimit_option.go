@@ -0,0 +1,41 @@
+package helix
+
+import (
+	"time"
+)
+
+// RateLimitOption is an optional setting for RateLimitTransport.
+type RateLimitOption func(*RateLimitTransport)
+
+// RateLimitWithMaxRetries sets maximum number of retries of the request rejected with 429 status code. The last
+// response is returned as is when retries are exhausted.
+//
+// Default value is 3.
+func RateLimitWithMaxRetries(retries int) RateLimitOption {
+	return func(rlt *RateLimitTransport) {
+		rlt.maxRetries = retries
+	}
+}
+
+// RateLimitWithOnUpdate sets callback that invokes synchronously each time Helix reports rate limit state, it's useful
+// to export rate limit metrics.
+func RateLimitWithOnUpdate(onUpdate func(RateLimit)) RateLimitOption {
+	return func(rlt *RateLimitTransport) {
+		rlt.onUpdate = onUpdate
+	}
+}
+
+// RateLimitWithOnWait sets callback that invokes synchronously each time request is queued until the bucket reset.
+func RateLimitWithOnWait(onWait func(time.Duration)) RateLimitOption {
+	return func(rlt *RateLimitTransport) {
+		rlt.onWait = onWait
+	}
+}
+
+// RateLimitWithOnRetry sets callback that invokes synchronously each time request rejected with 429 status code is
+// retried.
+func RateLimitWithOnRetry(onRetry func(attempt int)) RateLimitOption {
+	return func(rlt *RateLimitTransport) {
+		rlt.onRetry = onRetry
+	}
+}
@@ -0,0 +1,304 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/twirapp/twitchy/internal/json"
+)
+
+// ErrUnknownScope indicates that scope is not defined in the library.
+var ErrUnknownScope = errors.New("unknown scope")
+
+// knownScopes is a set of all scopes defined in the library.
+var knownScopes = NewScopes(
+	ScopeAnalyticsReadExtensions,
+	ScopeAnalyticsReadGames,
+	ScopeBitsRead,
+	ScopeChannelBot,
+	ScopeChannelManageAds,
+	ScopeChannelReadAds,
+	ScopeChannelManageBroadcast,
+	ScopeChannelReadCharity,
+	ScopeChannelEditCommercial,
+	ScopeChannelReadEditors,
+	ScopeChannelManageExtensions,
+	ScopeChannelReadGoals,
+	ScopeChannelReadGuestStar,
+	ScopeChannelManageGuestStar,
+	ScopeChannelReadHypeTrain,
+	ScopeChannelManageModerators,
+	ScopeChannelReadPolls,
+	ScopeChannelManagePolls,
+	ScopeChannelReadPredictions,
+	ScopeChannelManagePredictions,
+	ScopeChannelManageRaids,
+	ScopeChannelReadRedemptions,
+	ScopeChannelManageRedemptions,
+	ScopeChannelManageSchedule,
+	ScopeChannelReadStreamKey,
+	ScopeChannelReadSubscriptions,
+	ScopeChannelManageVideos,
+	ScopeChannelReadVIPs,
+	ScopeChannelManageVIPs,
+	ScopeChannelModerate,
+	ScopeClipsEdit,
+	ScopeModerationRead,
+	ScopeModeratorManageAnnouncements,
+	ScopeModeratorManageAutoMod,
+	ScopeModeratorReadAutoModSettings,
+	ScopeModeratorManageAutoModSettings,
+	ScopeModeratorReadBannedUsers,
+	ScopeModeratorManageBannedUsers,
+	ScopeModeratorReadBlockedTerms,
+	ScopeModeratorManageBlockedTerms,
+	ScopeModeratorReadChatMessages,
+	ScopeModeratorManageChatMessages,
+	ScopeModeratorReadChatSettings,
+	ScopeModeratorManageChatSettings,
+	ScopeModeratorReadChatters,
+	ScopeModeratorReadFollowers,
+	ScopeModeratorReadGuestStar,
+	ScopeModeratorManageGuestStar,
+	ScopeModeratorReadModerators,
+	ScopeModeratorReadShieldMode,
+	ScopeModeratorManageShieldMode,
+	ScopeModeratorReadShoutouts,
+	ScopeModeratorManageShoutouts,
+	ScopeModeratorReadSuspiciousUsers,
+	ScopeModeratorReadUnbanRequests,
+	ScopeModeratorManageUnbanRequests,
+	ScopeModeratorReadVIPs,
+	ScopeModeratorReadWarnings,
+	ScopeModeratorManageWarnings,
+	ScopeUserBot,
+	ScopeUserEdit,
+	ScopeUserEditBroadcast,
+	ScopeUserReadBlockedUsers,
+	ScopeUserManageBlockedUsers,
+	ScopeUserReadBroadcast,
+	ScopeUserReadChat,
+	ScopeUserManageChatColor,
+	ScopeUserReadEmail,
+	ScopeUserReadEmotes,
+	ScopeUserReadFollows,
+	ScopeUserReadModeratedChannels,
+	ScopeUserReadSubscriptions,
+	ScopeUserReadWhispers,
+	ScopeUserManageWhispers,
+	ScopeUserWriteChat,
+	ScopeChatEdit,
+	ScopeChatRead,
+)
+
+// deprecatedScopes maps deprecated IRC scopes to scopes that replace them in the Twitch API.
+//
+// Reference: https://dev.twitch.tv/docs/chat/irc-migration.
+var deprecatedScopes = map[AuthorizationScope]AuthorizationScope{
+	ScopeChatRead: ScopeUserReadChat,
+	ScopeChatEdit: ScopeUserWriteChat,
+}
+
+// IsKnown returns if scope is defined in the library.
+func (as AuthorizationScope) IsKnown() bool {
+	return knownScopes.Contains(as)
+}
+
+// IsDeprecated returns if scope is deprecated and should be replaced with the one returned by Replacement.
+func (as AuthorizationScope) IsDeprecated() bool {
+	_, ok := deprecatedScopes[as]
+	return ok
+}
+
+// Replacement returns scope that replaces deprecated scope, or false if scope is not deprecated.
+func (as AuthorizationScope) Replacement() (AuthorizationScope, bool) {
+	replacement, ok := deprecatedScopes[as]
+	return replacement, ok
+}
+
+func (as AuthorizationScope) String() string {
+	return string(as)
+}
+
+// Scopes is a set of authorization scopes.
+type Scopes map[AuthorizationScope]struct{}
+
+func NewScopes(scopes ...AuthorizationScope) Scopes {
+	set := make(Scopes, len(scopes))
+	for _, scope := range scopes {
+		set[scope] = struct{}{}
+	}
+
+	return set
+}
+
+// ParseScopes parses space-separated list of scopes (e.g. scope parameter of the authorization redirect). Unknown scopes
+// are kept as is.
+func ParseScopes(rawScopes string) Scopes {
+	fields := strings.Fields(rawScopes)
+
+	scopes := make(Scopes, len(fields))
+	for _, field := range fields {
+		scopes[AuthorizationScope(field)] = struct{}{}
+	}
+
+	return scopes
+}
+
+// ParseScopesStrict parses space-separated list of scopes like ParseScopes, but returns ErrUnknownScope if any of the
+// scopes is not defined in the library.
+func ParseScopesStrict(rawScopes string) (Scopes, error) {
+	scopes := ParseScopes(rawScopes)
+
+	if err := scopes.checkKnown(); err != nil {
+		return nil, err
+	}
+
+	return scopes, nil
+}
+
+// checkKnown returns ErrUnknownScope if any of the scopes is not defined in the library.
+func (s Scopes) checkKnown() error {
+	for _, scope := range s.Slice() {
+		if !scope.IsKnown() {
+			return fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+		}
+	}
+
+	return nil
+}
+
+// String returns sorted space-separated list of scopes as expected by the scope URL parameter.
+func (s Scopes) String() string {
+	return joinScopes(s.Slice())
+}
+
+// Slice returns sorted list of scopes.
+func (s Scopes) Slice() []AuthorizationScope {
+	scopes := make([]AuthorizationScope, 0, len(s))
+	for scope := range s {
+		scopes = append(scopes, scope)
+	}
+
+	slices.Sort(scopes)
+
+	return scopes
+}
+
+// Contains returns if set contains all the provided scopes.
+func (s Scopes) Contains(scopes ...AuthorizationScope) bool {
+	for _, scope := range scopes {
+		if _, ok := s[scope]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union returns new set with scopes of both sets.
+func (s Scopes) Union(other Scopes) Scopes {
+	union := make(Scopes, len(s)+len(other))
+
+	for scope := range s {
+		union[scope] = struct{}{}
+	}
+	for scope := range other {
+		union[scope] = struct{}{}
+	}
+
+	return union
+}
+
+// Difference returns new set with scopes that are in this set but not in the other one.
+func (s Scopes) Difference(other Scopes) Scopes {
+	difference := make(Scopes, len(s))
+
+	for scope := range s {
+		if _, ok := other[scope]; !ok {
+			difference[scope] = struct{}{}
+		}
+	}
+
+	return difference
+}
+
+// Deprecated returns sorted list of deprecated scopes in the set.
+func (s Scopes) Deprecated() []AuthorizationScope {
+	var deprecated []AuthorizationScope
+
+	for _, scope := range s.Slice() {
+		if scope.IsDeprecated() {
+			deprecated = append(deprecated, scope)
+		}
+	}
+
+	return deprecated
+}
+
+// Migrated returns new set where deprecated scopes are replaced with their replacements.
+func (s Scopes) Migrated() Scopes {
+	migrated := make(Scopes, len(s))
+
+	for scope := range s {
+		if replacement, ok := scope.Replacement(); ok {
+			scope = replacement
+		}
+
+		migrated[scope] = struct{}{}
+	}
+
+	return migrated
+}
+
+// MarshalJSON encodes scopes as sorted JSON array.
+func (s Scopes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON decodes scopes from either JSON array or space-separated JSON string as Twitch uses both formats.
+func (s *Scopes) UnmarshalJSON(payload []byte) error {
+	var rawScopes string
+
+	if err := json.Unmarshal(payload, &rawScopes); err == nil {
+		*s = ParseScopes(rawScopes)
+		return nil
+	}
+
+	var scopes []AuthorizationScope
+
+	if err := json.Unmarshal(payload, &scopes); err != nil {
+		return fmt.Errorf("unmarshal scopes: %w", err)
+	}
+
+	*s = NewScopes(scopes...)
+	return nil
+}
+
+// StrictScopes is a set of authorization scopes that is decoded from JSON like Scopes, but returns ErrUnknownScope if
+// any of the scopes is not defined in the library.
+type StrictScopes Scopes
+
+// MarshalJSON encodes scopes as sorted JSON array.
+func (s StrictScopes) MarshalJSON() ([]byte, error) {
+	return Scopes(s).MarshalJSON()
+}
+
+// UnmarshalJSON decodes scopes from either JSON array or space-separated JSON string like Scopes and checks that all of
+// them are defined in the library.
+func (s *StrictScopes) UnmarshalJSON(payload []byte) error {
+	var scopes Scopes
+
+	if err := scopes.UnmarshalJSON(payload); err != nil {
+		return err
+	}
+
+	if err := scopes.checkKnown(); err != nil {
+		return err
+	}
+
+	*s = StrictScopes(scopes)
+	return nil
+}
@@ -0,0 +1,51 @@
+package helix
+
+import (
+	"context"
+	"time"
+)
+
+// tokenExpiryDelta is a time before token expiration when token is already considered expired, so it's refreshed
+// before Twitch starts rejecting it.
+const tokenExpiryDelta = 5 * time.Minute
+
+// Token is an OAuth access token.
+type Token struct {
+	AccessToken string
+	// RefreshToken is a token used to refresh access token, it's empty for app access tokens.
+	RefreshToken string
+	// Scopes is a set of scopes granted to the token, it's empty for app access tokens.
+	Scopes Scopes
+	// ExpiresAt is a time when access token expires, it's zero if token has no known expiration time.
+	ExpiresAt time.Time
+}
+
+// IsExpired returns if token is expired or is about to expire.
+func (t Token) IsExpired() bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(tokenExpiryDelta).After(t.ExpiresAt)
+}
+
+// TokenSource is a source of access tokens that are used to authorize requests. Implementations must be safe for
+// concurrent use as the same TokenSource is used by every request.
+type TokenSource interface {
+	// Token returns valid access token.
+	Token(ctx context.Context) (Token, error)
+}
+
+// TokenSourceFunc is an adapter to use ordinary function as TokenSource.
+type TokenSourceFunc func(ctx context.Context) (Token, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns TokenSource that always returns the same token.
+func StaticTokenSource(token Token) TokenSource {
+	return TokenSourceFunc(func(context.Context) (Token, error) {
+		return token, nil
+	})
+}
@@ -0,0 +1,51 @@
+package helix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AppTokenSource is a TokenSource of app access tokens obtained with client credentials grant flow. Token is cached
+// and obtained again when it's about to expire. It's safe for concurrent use.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/#app-access-tokens.
+type AppTokenSource struct {
+	oauth *OAuth
+	token Token
+	mu    sync.Mutex
+}
+
+var _ TokenSource = (*AppTokenSource)(nil)
+
+func NewAppTokenSource(oauth *OAuth) *AppTokenSource {
+	return &AppTokenSource{
+		oauth: oauth,
+	}
+}
+
+func (ats *AppTokenSource) Token(ctx context.Context) (Token, error) {
+	ats.mu.Lock()
+	defer ats.mu.Unlock()
+
+	if ats.token.AccessToken != "" && !ats.token.IsExpired() {
+		return ats.token, nil
+	}
+
+	token, err := ats.oauth.AppToken(ctx)
+	if err != nil {
+		return Token{}, fmt.Errorf("get app token: %w", err)
+	}
+
+	ats.token = token
+	return token, nil
+}
+
+// Invalidate drops cached token, so new token will be obtained on the next call. It's useful when Twitch rejects the
+// token before its expiration (e.g. client secret was rotated).
+func (ats *AppTokenSource) Invalidate() {
+	ats.mu.Lock()
+	defer ats.mu.Unlock()
+
+	ats.token = Token{}
+}
@@ -0,0 +1,46 @@
+package helix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twirapp/twitchy/eventsub"
+)
+
+// TokenStore is a persistent storage of user access tokens by user id.
+type TokenStore interface {
+	// Save saves token of the user, replacing the previous one.
+	Save(ctx context.Context, userId string, token Token) error
+	// Delete deletes token of the user.
+	Delete(ctx context.Context, userId string) error
+}
+
+// OnAuthorizationRevoke returns eventsub handler for user.authorization.revoke event that deletes tokens of the user
+// who revoked authorization of your app from the store and invalidates token sources of the user in the registry, so
+// they stop serving and refreshing the revoked token. Both store and registry are optional. Errors of the store are
+// passed to onError if it's not nil.
+//
+// Usage example:
+//
+//	websocket.OnUserAuthorizationRevoke(
+//		helix.OnAuthorizationRevoke[eventsub.WebsocketNotificationMetadata](store, registry, nil),
+//	)
+func OnAuthorizationRevoke[Metadata any](
+	store TokenStore,
+	registry *UserTokenRegistry,
+	onError func(error),
+) eventsub.Handler[eventsub.UserAuthorizationRevokeEvent, Metadata] {
+	return func(event eventsub.UserAuthorizationRevokeEvent, _ Metadata) {
+		if registry != nil {
+			registry.Invalidate(event.UserId, fmt.Errorf("%w: user revoked authorization", ErrInvalidToken))
+		}
+
+		if store == nil {
+			return
+		}
+
+		if err := store.Delete(context.Background(), event.UserId); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
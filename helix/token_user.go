@@ -0,0 +1,155 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenValidationInterval is an interval of user access token validation recommended by Twitch.
+const tokenValidationInterval = 1 * time.Hour
+
+// UserTokenSource is a TokenSource of user access token that is refreshed when it's about to expire. It's safe for
+// concurrent use.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/#user-access-tokens.
+type UserTokenSource struct {
+	oauth *OAuth
+	token Token
+	err   error
+	mu    sync.Mutex
+
+	onRefresh func(Token)
+	onInvalid func(error)
+}
+
+var _ TokenSource = (*UserTokenSource)(nil)
+
+func NewUserTokenSource(oauth *OAuth, token Token, options ...UserTokenSourceOption) *UserTokenSource {
+	uts := &UserTokenSource{
+		oauth: oauth,
+		token: token,
+	}
+
+	for _, option := range options {
+		option(uts)
+	}
+
+	return uts
+}
+
+func (uts *UserTokenSource) Token(ctx context.Context) (Token, error) {
+	uts.mu.Lock()
+	defer uts.mu.Unlock()
+
+	if uts.err != nil {
+		return Token{}, uts.err
+	}
+
+	if !uts.token.IsExpired() {
+		return uts.token, nil
+	}
+
+	if err := uts.refresh(ctx); err != nil {
+		return Token{}, err
+	}
+
+	return uts.token, nil
+}
+
+// Refresh refreshes token regardless of its expiration time.
+func (uts *UserTokenSource) Refresh(ctx context.Context) error {
+	uts.mu.Lock()
+	defer uts.mu.Unlock()
+
+	return uts.refresh(ctx)
+}
+
+// Validate validates token and invalidates token source if token is no longer valid, so all subsequent calls to
+// Token will return ErrInvalidToken.
+func (uts *UserTokenSource) Validate(ctx context.Context) error {
+	token, err := uts.Token(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = uts.oauth.ValidateToken(ctx, token.AccessToken); err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			uts.Invalidate(err)
+		}
+
+		return fmt.Errorf("validate token: %w", err)
+	}
+
+	return nil
+}
+
+// StartValidationWorker starts and blocks on validating token on start and every hour after that as required by
+// Twitch, until context is canceled or token becomes invalid.
+//
+// Reference: https://dev.twitch.tv/docs/authentication/validate-tokens.
+func (uts *UserTokenSource) StartValidationWorker(ctx context.Context) {
+	ticker := time.NewTicker(tokenValidationInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := uts.Validate(ctx); errors.Is(err, ErrInvalidToken) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Invalidate makes token source unusable, so all subsequent calls to Token will return provided error. It's called
+// automatically when token is found invalid and can be called when user has revoked authorization of your app.
+func (uts *UserTokenSource) Invalidate(err error) {
+	uts.mu.Lock()
+	defer uts.mu.Unlock()
+
+	uts.invalidate(err)
+}
+
+// invalidate makes token source unusable if it's not already. It must be called with lock held.
+func (uts *UserTokenSource) invalidate(err error) {
+	if uts.err != nil {
+		return
+	}
+
+	uts.err = err
+	uts.token = Token{}
+
+	if uts.onInvalid != nil {
+		go uts.onInvalid(err)
+	}
+}
+
+// refresh refreshes token with the refresh token. It must be called with lock held.
+func (uts *UserTokenSource) refresh(ctx context.Context) error {
+	token, err := uts.oauth.RefreshToken(ctx, uts.token.RefreshToken)
+	if err != nil {
+		var apiError *APIError
+
+		// Twitch responds with bad request if refresh token is invalid, so user must authorize the app again.
+		if errors.As(err, &apiError) && apiError.Status == http.StatusBadRequest {
+			uts.invalidate(ErrInvalidToken)
+		}
+
+		return fmt.Errorf("refresh token: %w", err)
+	}
+
+	uts.token = token
+
+	if uts.onRefresh != nil {
+		uts.onRefresh(token)
+	}
+
+	return nil
+}
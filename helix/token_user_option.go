@@ -0,0 +1,19 @@
+package helix
+
+// UserTokenSourceOption is an optional setting for UserTokenSource.
+type UserTokenSourceOption func(*UserTokenSource)
+
+// UserTokenWithOnRefresh sets callback that invokes synchronously each time token is refreshed, so the new token
+// (including rotated refresh token) can be persisted before it's used. Callback must not call UserTokenSource methods.
+func UserTokenWithOnRefresh(onRefresh func(Token)) UserTokenSourceOption {
+	return func(uts *UserTokenSource) {
+		uts.onRefresh = onRefresh
+	}
+}
+
+// UserTokenWithOnInvalid sets callback that invokes when token source is invalidated (e.g. token has been revoked).
+func UserTokenWithOnInvalid(onInvalid func(error)) UserTokenSourceOption {
+	return func(uts *UserTokenSource) {
+		uts.onInvalid = onInvalid
+	}
+}
@@ -0,0 +1,65 @@
+package helix
+
+import "sync"
+
+// UserTokenRegistry is a registry of live user token sources by user id, so token sources of the user can be
+// invalidated when the user revokes authorization of your app (see OnAuthorizationRevoke). It's safe for concurrent use.
+type UserTokenRegistry struct {
+	mu      sync.Mutex
+	lastId  uint64
+	sources map[string]map[uint64]*UserTokenSource
+}
+
+func NewUserTokenRegistry() *UserTokenRegistry {
+	return &UserTokenRegistry{
+		sources: make(map[string]map[uint64]*UserTokenSource),
+	}
+}
+
+// Register adds token source of the user to the registry and returns function that removes it.
+func (r *UserTokenRegistry) Register(userId string, source *UserTokenSource) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastId++
+	id := r.lastId
+
+	userSources, ok := r.sources[userId]
+	if !ok {
+		userSources = make(map[uint64]*UserTokenSource)
+		r.sources[userId] = userSources
+	}
+
+	userSources[id] = source
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			r.unregister(userId, id)
+		})
+	}
+}
+
+func (r *UserTokenRegistry) unregister(userId string, id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sources[userId], id)
+
+	if len(r.sources[userId]) == 0 {
+		delete(r.sources, userId)
+	}
+}
+
+// Invalidate invalidates all token sources of the user with provided error and removes them from the registry.
+func (r *UserTokenRegistry) Invalidate(userId string, err error) {
+	r.mu.Lock()
+	userSources := r.sources[userId]
+	delete(r.sources, userId)
+	r.mu.Unlock()
+
+	for _, source := range userSources {
+		source.Invalidate(err)
+	}
+}
@@ -108,6 +108,15 @@ func (sm *ShardedMap[K, V]) GetOrSet(key K, value V) (V, bool) {
 	return value, false
 }
 
+func (sm *ShardedMap[K, V]) Delete(key K) {
+	shardEntry := sm.getShard(key)
+
+	shardEntry.Lock()
+	defer shardEntry.Unlock()
+
+	delete(shardEntry.entries, key)
+}
+
 func (sm *ShardedMap[K, V]) getShard(key K) *shard[K, V] {
 	hash := sm.hasher(key)
 	return sm.shards[hash%shardCount]